@@ -2,32 +2,97 @@ package analyzer
 
 import (
 	"flag"
+	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
 )
 
 const (
 	name = "ttempdir"
-	doc  = name + " is analyzer that detects using os.MkdirTemp, ioutil.TempDir or os.TempDir instead of t.TempDir since Go1.15" //nolint:lll
+	doc  = name + " is analyzer that detects using os.MkdirTemp, ioutil.TempDir, os.TempDir, os.CreateTemp or ioutil.TempFile instead of t.TempDir since Go1.15" //nolint:lll
 	url  = "https://github.com/peczenyj/ttempdir"
 
 	defaultAll               = false
-	defaultMaxRecursionLevel = 5 // arbitrary value, just to avoid too many recursion calls
+	defaultGinkgo            = false
+	defaultLeak              = false
+	defaultModernize         = false
+	defaultGenerated         = false
+	defaultUnusedDirectives  = false
+	defaultTempDirMethod     = "TempDir"
+	defaultMaxRecursionLevel = 0 // no limit, the whole expression tree is visited
 
 	// FlagAllName name of the 'all' flag in cli.
 	FlagAllName = "all"
 	// FlagMaxRecursionLevelName name of the 'max-recursion-level' flag in cli.
 	FlagMaxRecursionLevelName = "max-recursion-level"
+	// FlagGinkgoName name of the 'ginkgo' flag in cli.
+	FlagGinkgoName = "ginkgo"
+	// FlagContextTypeName name of the 'context-type' flag in cli.
+	FlagContextTypeName = "context-type"
+	// FlagLeakName name of the 'leak' flag in cli.
+	FlagLeakName = "leak"
+	// FlagModernizeName name of the 'modernize' flag in cli.
+	FlagModernizeName = "modernize"
+	// FlagGoVersionName name of the 'go-version' flag in cli.
+	FlagGoVersionName = "go-version"
+	// FlagGeneratedName name of the 'generated' flag in cli.
+	FlagGeneratedName = "generated"
+	// FlagIncludeName name of the 'include' flag in cli.
+	FlagIncludeName = "include"
+	// FlagExcludeName name of the 'exclude' flag in cli.
+	FlagExcludeName = "exclude"
+	// FlagIncludePackageName name of the 'include-package' flag in cli.
+	FlagIncludePackageName = "include-package"
+	// FlagExcludePackageName name of the 'exclude-package' flag in cli.
+	FlagExcludePackageName = "exclude-package"
+	// FlagFuncName name of the 'func' flag in cli.
+	FlagFuncName = "func"
+	// FlagUnusedDirectivesName name of the 'unused-directives' flag in cli.
+	FlagUnusedDirectivesName = "unused-directives"
+
+	// CategoryLeak category of the diagnostics of temporary directories and files that are never removed.
+	CategoryLeak = "leak"
+	// CategoryStyle category of the other diagnostics.
+	CategoryStyle = "style"
+	// CategoryDirective category of the diagnostics of the suppression directives.
+	CategoryDirective = "directive"
+	// CategoryConfig category of the diagnostics of the invalid configuration files.
+	CategoryConfig = "config"
 )
 
 type ttempdirAnalyzer struct {
-	all               bool
-	maxRecursionLevel uint
+	Config
+	configs *configCache
+}
+
+// passState holds the state shared by the checks of a pass.
+type passState struct {
+	values valueIndex
+	// goVersion overrides the Go version of the files, if set.
+	goVersion string
+	// reportedCalls records the calls reported by the testing rule.
+	reportedCalls map[*ast.CallExpr]bool
+	// skippedFiles records the files that are not checked.
+	skippedFiles map[*token.File]bool
+	// closureNames maps the function literals to their name, see nameClosures.
+	closureNames map[*ast.FuncLit]string
+	// osEdits records the positions of the edits that refer to the os
+	// package, whose import must then be kept or added.
+	osEdits map[token.Pos]bool
+	// diagnostics are the diagnostics not reported yet, see reportDiagnostics.
+	diagnostics []analysis.Diagnostic
+}
+
+// isSkipped reports whether the file that contains node is not checked.
+func (state *passState) isSkipped(pass *analysis.Pass, node ast.Node) bool {
+	return state.skippedFiles[pass.Fset.File(node.Pos())]
 }
 
 type conf struct {
@@ -45,15 +110,39 @@ func WithFlagPrefix(prefix string) Option {
 }
 
 // New analyzer constructor.
-// Will bind flagset all and max-recursion-level.
+// Will bind flagset all, max-recursion-level, ginkgo, leak, modernize, go-version, generated, include, exclude,
+// include-package, exclude-package, unused-directives, func and context-type.
 func New(opts ...Option) *analysis.Analyzer {
+	return newAnalyzer(defaultConfig(), opts...)
+}
+
+// NewWithConfig analyzer constructor, with the options of cfg instead of the
+// default ones. The flags still override them.
+// Returns an error if cfg holds an invalid value.
+func NewWithConfig(cfg Config, opts ...Option) (*analysis.Analyzer, error) {
+	cfg, err := cfg.normalized()
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s config: %w", name, err)
+	}
+
+	return newAnalyzer(cfg, opts...), nil
+}
+
+func newAnalyzer(cfg Config, opts ...Option) *analysis.Analyzer {
 	var config conf
 
 	for _, opt := range opts {
 		opt(&config)
 	}
 
-	var instance ttempdirAnalyzer
+	if cfg.ContextTypes == nil {
+		cfg.ContextTypes = make(map[string]string)
+	}
+
+	instance := ttempdirAnalyzer{
+		Config:  cfg,
+		configs: newConfigCache(),
+	}
 
 	analyzer := &analysis.Analyzer{
 		Name: name,
@@ -63,76 +152,233 @@ func New(opts ...Option) *analysis.Analyzer {
 		Requires: []*analysis.Analyzer{
 			inspect.Analyzer,
 		},
+		FactTypes: []analysis.Fact{
+			new(tempDirFact),
+		},
 	}
 
-	config.bindFlags(&instance, &analyzer.Flags)
+	config.bindFlags(&instance.Config, &analyzer.Flags)
 
 	return analyzer
 }
 
-func (c *conf) bindFlags(instance *ttempdirAnalyzer, flagSet *flag.FlagSet) {
+// bindFlags binds the flags to the options of cfg, with its current values as defaults.
+func (c *conf) bindFlags(cfg *Config, flagSet *flag.FlagSet) {
 	prefix := c.prefix
 
 	if prefix != "" && !strings.HasSuffix(prefix, ".") {
 		prefix += "."
 	}
 
-	flagSet.BoolVar(&instance.all,
+	flagSet.BoolVar(&cfg.All,
 		prefix+FlagAllName,
-		defaultAll,
+		cfg.All,
 		"the all option will run against all methods in test file")
 
-	flagSet.UintVar(&instance.maxRecursionLevel,
+	flagSet.UintVar(&cfg.MaxRecursionLevel,
 		prefix+FlagMaxRecursionLevelName,
-		defaultMaxRecursionLevel,
-		"max recursion level when checking nested arg calls")
+		cfg.MaxRecursionLevel,
+		"max level of nested calls visited when checking an expression, 0 means no limit")
+
+	flagSet.BoolVar(&cfg.Ginkgo,
+		prefix+FlagGinkgoName,
+		cfg.Ginkgo,
+		"the ginkgo option will check closures passed to Ginkgo specs and setup nodes, like It or BeforeEach")
+
+	flagSet.BoolVar(&cfg.Leak,
+		prefix+FlagLeakName,
+		cfg.Leak,
+		"the leak option will check non-test code for temporary directories and files "+
+			"that are not removed on every return path")
+
+	flagSet.BoolVar(&cfg.Modernize,
+		prefix+FlagModernizeName,
+		cfg.Modernize,
+		"the modernize option will check all files for the deprecated ioutil.TempDir and ioutil.TempFile functions")
+
+	flagSet.Var((*goVersionFlag)(&cfg.GoVersion),
+		prefix+FlagGoVersionName,
+		"the Go version of the checked code, like 1.21, instead of the one of go.mod or of the //go:build constraints")
+
+	flagSet.BoolVar(&cfg.Generated,
+		prefix+FlagGeneratedName,
+		cfg.Generated,
+		"the generated option will also check the generated files, with a // Code generated ... DO NOT EDIT. header")
+
+	flagSet.Var(newGlobList(&cfg.Include),
+		prefix+FlagIncludeName,
+		"comma separated list of path globs, like internal/*_test.go, only the matching files are checked")
+
+	flagSet.Var(newGlobList(&cfg.Exclude),
+		prefix+FlagExcludeName,
+		"comma separated list of path globs, like mocks/*.go, the matching files are not checked")
+
+	flagSet.Var(newPackagePatternList(&cfg.IncludePackages),
+		prefix+FlagIncludePackageName,
+		"comma separated list of package patterns, like github.com/acme/app/..., only the matching packages are checked")
+
+	flagSet.Var(newPackagePatternList(&cfg.ExcludePackages),
+		prefix+FlagExcludePackageName,
+		"comma separated list of package patterns, like github.com/acme/app/mocks/..., the matching packages are not checked")
+
+	flagSet.BoolVar(&cfg.UnusedDirectives,
+		prefix+FlagUnusedDirectivesName,
+		cfg.UnusedDirectives,
+		"the unused-directives option will report the //nolint:ttempdir and //ttempdir:ignore directives "+
+			"that suppress nothing")
+
+	flagSet.Var(newFunctionList(&cfg.Functions),
+		prefix+FlagFuncName,
+		"a function that creates temporary directories, like github.com/acme/fsutil.MakeScratch, "+
+			"optionally followed by =<message> to replace the suggestion of the diagnostics, can be repeated")
+
+	flagSet.Var(contextTypes(cfg.ContextTypes),
+		prefix+FlagContextTypeName,
+		"comma separated list of test context types and the method that returns a temporary directory, "+
+			"like gopkg.in/check.v1.C=MkDir")
 }
 
 func (ta *ttempdirAnalyzer) Run(pass *analysis.Pass) (interface{}, error) {
+	if isStandardLibrary(pass) {
+		return nil, nil //nolint:nilnil //no problem in return nil,nil here
+	}
+
+	instance, err := ta.forPass(pass)
+	if err != nil {
+		// the packages only analyzed for their facts, like the dependencies,
+		// must not fail the run, so the error is reported instead.
+		reportConfigError(pass, err)
+
+		instance = ta
+	}
+
+	instance.run(pass)
+
+	return nil, nil //nolint:nilnil //no problem in return nil,nil here
+}
+
+func (ta *ttempdirAnalyzer) run(pass *analysis.Pass) {
 	theInspector, _ := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
+	values := newValueIndex(pass, theInspector)
+
+	// the facts are exported even for the code that is not checked, since it may be called by checked code.
+	ta.exportTempDirFacts(pass, &passState{values: values}, theInspector)
+
+	if !ta.isPackageChecked(pass) {
+		return
+	}
+
+	state := &passState{
+		values:        values,
+		goVersion:     ta.GoVersion,
+		reportedCalls: make(map[*ast.CallExpr]bool),
+		skippedFiles:  ta.skippedFiles(pass),
+		closureNames:  ta.nameClosures(pass, theInspector),
+		osEdits:       make(map[token.Pos]bool),
+	}
+
+	directives := parseDirectives(pass, state.skippedFiles)
+	filteredPass := directives.filter(state.bufferReports(pass))
+
 	nodeFilter := []ast.Node{
 		(*ast.FuncDecl)(nil),
 		(*ast.FuncLit)(nil),
 	}
 
-	theInspector.Preorder(nodeFilter, func(node ast.Node) {
-		ta.checkAstNode(pass, node)
+	theInspector.WithStack(nodeFilter, func(node ast.Node, push bool, stack []ast.Node) bool {
+		if push && state.isSkipped(pass, node) {
+			return false
+		}
+
+		if push {
+			ta.checkAstNode(filteredPass, state, node, stack)
+		}
+
+		return true
 	})
 
-	return nil, nil //nolint:nilnil //no problem in return nil,nil here
+	if ta.Modernize {
+		ta.checkDeprecatedCalls(filteredPass, state, theInspector)
+	}
+
+	newReporterBuilder(pass, state, "", "", "", nil).reportDiagnostics()
+
+	directives.report(pass, ta.UnusedDirectives)
 }
 
-func (ta *ttempdirAnalyzer) checkAstNode(pass *analysis.Pass, node ast.Node) {
+func (ta *ttempdirAnalyzer) checkAstNode(pass *analysis.Pass,
+	state *passState,
+	node ast.Node,
+	stack []ast.Node,
+) {
 	switch function := node.(type) {
 	case *ast.FuncDecl:
-		ta.checkFuncDecl(pass, function)
+		ta.checkFuncDecl(pass, state, function)
 	case *ast.FuncLit:
-		ta.checkFuncLit(pass, function, "anonymous function")
+		ta.checkFuncLit(pass, state, function, stack, state.closureName(function))
 	}
 }
 
-func (ta *ttempdirAnalyzer) checkFuncDecl(pass *analysis.Pass, function *ast.FuncDecl) {
-	ta.checkGenericFunctionCall(pass, function.Type, function.Body, function.Name.Name)
+func (ta *ttempdirAnalyzer) checkFuncDecl(pass *analysis.Pass, state *passState, function *ast.FuncDecl) {
+	ta.checkGenericFunctionCall(pass, state, function.Recv, function.Type, nil, function.Body, function.Name.Name)
 }
 
-func (ta *ttempdirAnalyzer) checkFuncLit(pass *analysis.Pass, function *ast.FuncLit, targetFunctionName string) {
-	ta.checkGenericFunctionCall(pass, function.Type, function.Body, targetFunctionName)
+func (ta *ttempdirAnalyzer) checkFuncLit(pass *analysis.Pass,
+	state *passState,
+	function *ast.FuncLit,
+	stack []ast.Node,
+	targetFunctionName string,
+) {
+	ta.checkGenericFunctionCall(pass, state, nil, function.Type, stack, function.Body, targetFunctionName)
 }
 
 func (ta *ttempdirAnalyzer) checkGenericFunctionCall(pass *analysis.Pass,
+	state *passState,
+	functionRecv *ast.FieldList,
 	functionType *ast.FuncType,
+	stack []ast.Node,
 	functionBody *ast.BlockStmt,
 	targetFunctionName string,
 ) {
-	variableOrPackageName, found := ta.targetRunner(functionType.Params,
-		isFilenameFollowingTestingConventions(pass, functionType.Pos()),
-	)
+	if functionBody == nil {
+		return
+	}
+
+	isTestFile := isFilenameFollowingTestingConventions(pass, functionType.Pos())
+
+	variableOrPackageName, tempDirMethod, declaration, found := ta.targetRunner(pass,
+		functionRecv, functionType, stack, isTestFile)
 
 	if found {
-		reporterBuilder := newReporterBuilder(pass, variableOrPackageName, targetFunctionName)
+		reporterBuilder := newReporterBuilder(pass,
+			state,
+			variableOrPackageName,
+			tempDirMethod,
+			targetFunctionName,
+			declaration)
 
 		ta.checkStmts(reporterBuilder, functionBody.List)
+
+		return
+	}
+
+	if ta.Leak && !isTestFile {
+		checker := newLeakReporter(newReporterBuilder(pass, state, "", "", targetFunctionName, nil), functionBody)
+
+		checker.checkLeaks(functionBody.List, nil)
+	}
+}
+
+// functionSignature returns the receiver, if any, and the type of a FuncDecl or FuncLit.
+func functionSignature(function ast.Node) (*ast.FieldList, *ast.FuncType) {
+	switch function := function.(type) {
+	case *ast.FuncDecl:
+		return function.Recv, function.Type
+	case *ast.FuncLit:
+		return nil, function.Type
+	default:
+		return nil, nil
 	}
 }
 
@@ -145,7 +391,15 @@ func isFilenameFollowingTestingConventions(pass *analysis.Pass, pos token.Pos) b
 func (ta *ttempdirAnalyzer) checkStmts(reporterBuilder *passReporterBuilder,
 	stmts []ast.Stmt,
 ) {
-	for _, stmt := range stmts {
+	for index, stmt := range stmts {
+		if assignStmt, ok := stmt.(*ast.AssignStmt); ok {
+			reporters := reporterBuilder.BuildForAssign(assignStmt, stmts[index+1:])
+
+			ta.checkAssignStmt(reporterBuilder, assignStmt, reporters)
+
+			continue
+		}
+
 		ta.checkSingleStmt(reporterBuilder, stmt)
 	}
 }
@@ -155,175 +409,448 @@ func (ta *ttempdirAnalyzer) checkSingleStmt(reporterBuilder *passReporterBuilder
 ) {
 	switch stmt := stmt.(type) {
 	case *ast.ExprStmt:
-		ta.checkExprStmt(reporterBuilder, stmt)
+		ta.checkExpr(reporterBuilder, stmt.X)
 	case *ast.IfStmt:
 		ta.checkIfStmt(reporterBuilder, stmt)
 	case *ast.AssignStmt:
-		ta.checkAssignStmt(reporterBuilder.Build(stmt.Pos()), stmt)
+		ta.checkAssignStmt(reporterBuilder, stmt, nil)
 	case *ast.ForStmt:
 		ta.checkForStmt(reporterBuilder, stmt)
+	case *ast.RangeStmt:
+		ta.checkRangeStmt(reporterBuilder, stmt)
 	case *ast.DeferStmt:
 		ta.checkDeferStmt(reporterBuilder, stmt)
+	case *ast.GoStmt:
+		ta.checkGoStmt(reporterBuilder, stmt)
+	case *ast.ReturnStmt:
+		ta.checkExprs(reporterBuilder, stmt.Results)
+	case *ast.DeclStmt:
+		ta.checkDeclStmt(reporterBuilder, stmt)
+	case *ast.SendStmt:
+		ta.checkExprs(reporterBuilder, []ast.Expr{stmt.Chan, stmt.Value})
+	case *ast.IncDecStmt:
+		ta.checkExpr(reporterBuilder, stmt.X)
+	case *ast.BlockStmt:
+		ta.checkStmts(reporterBuilder, stmt.List)
+	case *ast.LabeledStmt:
+		ta.checkSingleStmt(reporterBuilder, stmt.Stmt)
+	case *ast.SwitchStmt:
+		ta.checkSwitchStmt(reporterBuilder, stmt)
+	case *ast.TypeSwitchStmt:
+		ta.checkTypeSwitchStmt(reporterBuilder, stmt)
+	case *ast.SelectStmt:
+		ta.checkSelectStmt(reporterBuilder, stmt)
+	case *ast.CaseClause:
+		ta.checkExprs(reporterBuilder, stmt.List)
+		ta.checkStmts(reporterBuilder, stmt.Body)
+	case *ast.CommClause:
+		ta.checkOptionalStmt(reporterBuilder, stmt.Comm)
+		ta.checkStmts(reporterBuilder, stmt.Body)
 	}
 }
 
-func (ta *ttempdirAnalyzer) checkExprStmt(reporterBuilder *passReporterBuilder,
-	stmt *ast.ExprStmt,
+func (ta *ttempdirAnalyzer) checkOptionalStmt(reporterBuilder *passReporterBuilder,
+	stmt ast.Stmt,
 ) {
-	if callExpr, ok := stmt.X.(*ast.CallExpr); ok {
-		ta.checkCallExpr(reporterBuilder, callExpr)
+	if stmt != nil {
+		ta.checkSingleStmt(reporterBuilder, stmt)
 	}
 }
 
-func (ta *ttempdirAnalyzer) checkCallExprRecursive(reporterBuilder *passReporterBuilder,
-	callExpr *ast.CallExpr,
-	currentRecursionLevel uint,
+func (ta *ttempdirAnalyzer) checkExprs(reporterBuilder *passReporterBuilder,
+	exprs []ast.Expr,
 ) {
-	if currentRecursionLevel == 0 {
-		return
-	}
-
-	currentRecursionLevel--
-
-	for _, arg := range callExpr.Args {
-		if argCallExpr, ok := arg.(*ast.CallExpr); ok {
-			ta.checkCallExprRecursive(reporterBuilder,
-				argCallExpr,
-				currentRecursionLevel,
-			)
-		}
+	for _, expr := range exprs {
+		ta.checkExpr(reporterBuilder, expr)
 	}
+}
 
-	reporter := reporterBuilder.Build(callExpr.Pos())
-
-	ta.checkFunctionExpr(reporter, callExpr.Fun)
+func (ta *ttempdirAnalyzer) checkExpr(reporterBuilder *passReporterBuilder,
+	expr ast.Expr,
+) {
+	newExprVisitor(ta, reporterBuilder, nil).walk(expr)
 }
 
 func (ta *ttempdirAnalyzer) checkIfStmt(reporterBuilder *passReporterBuilder,
 	stmt *ast.IfStmt,
 ) {
 	if assignStmt, ok := stmt.Init.(*ast.AssignStmt); ok {
-		reporter := reporterBuilder.Build(stmt.Pos())
+		reporters := reporterBuilder.BuildForIfInit(stmt, assignStmt)
 
-		ta.checkAssignStmt(reporter, assignStmt)
+		ta.checkAssignStmt(reporterBuilder, assignStmt, reporters)
+	} else {
+		ta.checkOptionalStmt(reporterBuilder, stmt.Init)
 	}
+
+	ta.checkExpr(reporterBuilder, stmt.Cond)
+	ta.checkStmts(reporterBuilder, stmt.Body.List)
+	ta.checkOptionalStmt(reporterBuilder, stmt.Else)
 }
 
-func (ta *ttempdirAnalyzer) checkAssignStmt(reporter *passReporter,
+// checkAssignStmt checks an assignment, with the reporters of its values,
+// if any, see BuildForAssign.
+func (ta *ttempdirAnalyzer) checkAssignStmt(reporterBuilder *passReporterBuilder,
 	stmt *ast.AssignStmt,
+	reporters []*passReporter,
 ) {
-	if rhs, ok := stmt.Rhs[0].(*ast.CallExpr); ok {
-		ta.checkFunctionExpr(reporter, rhs.Fun)
+	ta.checkExprs(reporterBuilder, stmt.Lhs)
+
+	for index, expr := range stmt.Rhs {
+		var reporter *passReporter
+		if index < len(reporters) {
+			reporter = reporters[index]
+		}
+
+		newExprVisitor(ta, reporterBuilder, reporter).walk(expr)
 	}
 }
 
 func (ta *ttempdirAnalyzer) checkDeferStmt(reporterBuilder *passReporterBuilder,
 	stmt *ast.DeferStmt,
 ) {
-	ta.checkCallExpr(reporterBuilder, stmt.Call)
+	ta.checkExpr(reporterBuilder, stmt.Call)
+}
+
+func (ta *ttempdirAnalyzer) checkGoStmt(reporterBuilder *passReporterBuilder,
+	stmt *ast.GoStmt,
+) {
+	ta.checkExpr(reporterBuilder, stmt.Call)
+}
+
+func (ta *ttempdirAnalyzer) checkDeclStmt(reporterBuilder *passReporterBuilder,
+	stmt *ast.DeclStmt,
+) {
+	genDecl, ok := stmt.Decl.(*ast.GenDecl)
+	if !ok {
+		return
+	}
+
+	for _, spec := range genDecl.Specs {
+		if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+			ta.checkExprs(reporterBuilder, valueSpec.Values)
+		}
+	}
 }
 
 func (ta *ttempdirAnalyzer) checkForStmt(reporterBuilder *passReporterBuilder,
 	stmt *ast.ForStmt,
 ) {
+	ta.checkOptionalStmt(reporterBuilder, stmt.Init)
+	ta.checkExpr(reporterBuilder, stmt.Cond)
+	ta.checkOptionalStmt(reporterBuilder, stmt.Post)
 	ta.checkStmts(reporterBuilder, stmt.Body.List)
 }
 
-func (ta *ttempdirAnalyzer) checkCallExpr(reporterBuilder *passReporterBuilder,
-	callExpr *ast.CallExpr,
+func (ta *ttempdirAnalyzer) checkRangeStmt(reporterBuilder *passReporterBuilder,
+	stmt *ast.RangeStmt,
 ) {
-	ta.checkCallExprRecursive(reporterBuilder,
-		callExpr,
-		ta.maxRecursionLevel,
-	)
+	ta.checkExprs(reporterBuilder, []ast.Expr{stmt.Key, stmt.Value, stmt.X})
+	ta.checkStmts(reporterBuilder, stmt.Body.List)
 }
 
-func (ta *ttempdirAnalyzer) checkFunctionExpr(reporter *passReporter,
-	functionExpr ast.Expr,
+func (ta *ttempdirAnalyzer) checkSwitchStmt(reporterBuilder *passReporterBuilder,
+	stmt *ast.SwitchStmt,
 ) {
-	if selectorExpr, ok := functionExpr.(*ast.SelectorExpr); ok {
-		ta.checkSelectorExpr(reporter, selectorExpr)
-	}
+	ta.checkOptionalStmt(reporterBuilder, stmt.Init)
+	ta.checkExpr(reporterBuilder, stmt.Tag)
+	ta.checkStmts(reporterBuilder, stmt.Body.List)
 }
 
-func (ta *ttempdirAnalyzer) checkSelectorExpr(reporter *passReporter,
-	selectorExpr *ast.SelectorExpr,
+func (ta *ttempdirAnalyzer) checkTypeSwitchStmt(reporterBuilder *passReporterBuilder,
+	stmt *ast.TypeSwitchStmt,
 ) {
-	if expression, ok := selectorExpr.X.(*ast.Ident); ok {
-		ta.checkIdentifiers(reporter, selectorExpr.Sel.Name, expression.Name)
+	ta.checkOptionalStmt(reporterBuilder, stmt.Init)
+	ta.checkSingleStmt(reporterBuilder, stmt.Assign)
+	ta.checkStmts(reporterBuilder, stmt.Body.List)
+}
+
+func (ta *ttempdirAnalyzer) checkSelectStmt(reporterBuilder *passReporterBuilder,
+	stmt *ast.SelectStmt,
+) {
+	ta.checkStmts(reporterBuilder, stmt.Body.List)
+}
+
+func (ta *ttempdirAnalyzer) checkFunctionExpr(reporter *passReporter,
+	callExpr *ast.CallExpr,
+) {
+	// a call may be reached twice, e.g. by the checks of nested function literals.
+	if reporter.builder.state.reportedCalls[callExpr] {
+		return
+	}
+
+	if function, ok := typeutil.Callee(reporter.TypesInfo(), callExpr).(*types.Func); ok {
+		ta.checkFunction(reporter, function, callExpr)
 	}
 }
 
-func (ta *ttempdirAnalyzer) checkIdentifiers(reporter *passReporter,
-	functionName string,
-	pkgName string,
+func (ta *ttempdirAnalyzer) checkFunction(reporter *passReporter,
+	function *types.Func,
+	callExpr *ast.CallExpr,
 ) {
-	fullQualifiedFunctionName := pkgName + "." + functionName
+	if isTempDirFunction(function) {
+		// subdirectories of a testing temp dir, like `os.MkdirTemp(t.TempDir(), "x")`, are removed with it.
+		if len(callExpr.Args) > 0 && ta.isInTestingTempDir(reporter.builder, callExpr.Args[0]) {
+			return
+		}
+
+		reporter.builder.state.reportedCalls[callExpr] = true
+		reporter.Report(callExpr,
+			qualifiedFunctionName(function),
+			createsTempDir(function),
+			reporter.builder.tempDirFix(callExpr))
+
+		return
+	}
+
+	if extra, ok := ta.lookupFunction(function); ok {
+		reporter.builder.state.reportedCalls[callExpr] = true
+		reporter.ReportFunction(callExpr, qualifiedFunctionName(function), extra.Message)
+
+		return
+	}
+
+	if isTempFileFunction(function) && reporter.builder.isInDefaultTempDir(callExpr.Args[0]) {
+		reporter.builder.state.reportedCalls[callExpr] = true
+		reporter.ReportTempFile(callExpr, qualifiedFunctionName(function), reporter.builder.createTempFix(callExpr, nil, nil))
+
+		return
+	}
 
-	switch fullQualifiedFunctionName {
-	case "ioutil.TempDir", "os.MkdirTemp", "os.TempDir":
-		reporter.Report(fullQualifiedFunctionName)
+	if fact, ok := ta.lookupTempDirFact(reporter.builder.pass, function); ok {
+		reporter.builder.state.reportedCalls[callExpr] = true
+		reporter.ReportCallChain(callExpr, qualifiedFunctionName(function), fact)
 	}
 }
 
-func (ta *ttempdirAnalyzer) targetRunner(
-	functionTypeParams *ast.FieldList,
+// isInTestingTempDir reports whether dir derives from the temp dir method of
+// a testing type, like `t.TempDir()`.
+func (ta *ttempdirAnalyzer) isInTestingTempDir(reporterBuilder *passReporterBuilder, dir ast.Expr) bool {
+	info := reporterBuilder.pass.TypesInfo
+
+	return reporterBuilder.state.values.derivesFrom(info, dir, func(call *ast.CallExpr) bool {
+		return ta.isTestingTempDirMethod(typeutil.Callee(info, call))
+	})
+}
+
+// targetRunner returns the expression that provides a temporary directory
+// in the function, like `t`, its temp dir method and the declaration of the
+// testing parameter or receiver it is based on, if any.
+func (ta *ttempdirAnalyzer) targetRunner(pass *analysis.Pass,
+	functionRecv *ast.FieldList,
+	functionType *ast.FuncType,
+	stack []ast.Node,
 	isTestFile bool,
-) (variableOrPackageName string, found bool) {
-	for _, field := range functionTypeParams.List {
-		if checkFieldType(field.Type, "testing") {
-			return getFirstFieldName(field)
+) (variableOrPackageName, tempDirMethod string, declaration *ast.Ident, found bool) {
+	goVersion := fileGoVersion(pass, ta.GoVersion, functionType.Pos())
+
+	if field, method, ok := ta.findTestingParam(pass, functionType, goVersion); ok {
+		declaration, found = getFirstFieldIdent(field)
+		if !found {
+			return "", method, nil, false
 		}
+
+		return declaration.Name, method, declaration, true
 	}
 
-	if ta.all && isTestFile {
-		return "", true
+	if variableName, method, receiver, ok := ta.findSuiteReceiver(pass, functionRecv, goVersion); ok {
+		return variableName, method, receiver, true
 	}
 
-	return "", false
+	if runner, ok := ta.ginkgoSpecRunner(pass, stack, len(stack)-1); ok {
+		return runner, defaultTempDirMethod, nil, true
+	}
+
+	if variableName, method, captured, ok := ta.findCapturedTestingVariable(pass, functionType, stack, goVersion); ok {
+		return variableName, method, captured, true
+	}
+
+	if ta.All && isTestFile && isGoVersionAtLeast(goVersion, goVersionTempDir) {
+		return "", defaultTempDirMethod, nil, true
+	}
+
+	return "", "", nil, false
 }
 
-func checkFieldType(fieldType ast.Expr, pkgName string) bool {
-	switch typ := fieldType.(type) {
-	case *ast.StarExpr:
-		return checkStarExprTarget(typ, pkgName, "T", "B", "F")
-	case *ast.SelectorExpr:
-		return checkSelectorExprTarget(typ, pkgName, "TB")
-	default:
+func (ta *ttempdirAnalyzer) findTestingParam(pass *analysis.Pass,
+	functionType *ast.FuncType,
+	goVersion string,
+) (field *ast.Field, tempDirMethod string, found bool) {
+	for _, field := range functionType.Params.List {
+		if method, ok := ta.checkFieldType(pass.TypesInfo.TypeOf(field.Type), goVersion); ok {
+			return field, method, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// isTestFunction reports whether the function declaration has a testing
+// parameter or is a method of a test suite.
+func (ta *ttempdirAnalyzer) isTestFunction(pass *analysis.Pass, function *ast.FuncDecl) bool {
+	if _, _, found := ta.findTestingParam(pass, function.Type, ""); found {
+		return true
+	}
+
+	if function.Recv == nil || len(function.Recv.List) == 0 {
 		return false
 	}
+
+	_, found := ta.checkSuiteType(pass.TypesInfo.TypeOf(function.Recv.List[0].Type), "")
+
+	return found
+}
+
+// findSuiteReceiver returns the expression that provides the testing value
+// of a suite method receiver, like `s.T()`, and the name of the receiver.
+func (ta *ttempdirAnalyzer) findSuiteReceiver(pass *analysis.Pass,
+	functionRecv *ast.FieldList,
+	goVersion string,
+) (variableName, tempDirMethod string, receiver *ast.Ident, found bool) {
+	if functionRecv == nil || len(functionRecv.List) == 0 {
+		return "", "", nil, false
+	}
+
+	field := functionRecv.List[0]
+
+	method, ok := ta.checkSuiteType(pass.TypesInfo.TypeOf(field.Type), goVersion)
+	if !ok {
+		return "", "", nil, false
+	}
+
+	if name, ok := getFirstFieldIdent(field); ok && name.Name != "_" {
+		return name.Name + ".T()", method, name, true
+	}
+
+	return "", "", nil, false
 }
 
-func checkStarExprTarget(typ *ast.StarExpr, pkgName string, selectorNames ...string) bool {
-	if selectorExpr, ok := typ.X.(*ast.SelectorExpr); ok {
-		return checkSelectorExprTarget(selectorExpr, pkgName, selectorNames...)
+// findCapturedTestingVariable returns the nearest testing parameter, suite
+// receiver or Ginkgo spec of the functions enclosing the function literal
+// at the top of the stack that is still visible from it, with the name of
+// the parameter or receiver, if any.
+func (ta *ttempdirAnalyzer) findCapturedTestingVariable(pass *analysis.Pass,
+	functionType *ast.FuncType,
+	stack []ast.Node,
+	goVersion string,
+) (variableName, tempDirMethod string, declaration *ast.Ident, found bool) {
+	scope := pass.TypesInfo.Scopes[functionType]
+	if scope == nil {
+		return "", "", nil, false
+	}
+
+	for i := len(stack) - 2; i >= 0; i-- {
+		enclosingRecv, enclosingFunctionType := functionSignature(stack[i])
+		if enclosingFunctionType == nil {
+			continue
+		}
+
+		for _, field := range enclosingFunctionType.Params.List {
+			method, ok := ta.checkFieldType(pass.TypesInfo.TypeOf(field.Type), goVersion)
+			if !ok {
+				continue
+			}
+
+			for _, fieldName := range field.Names {
+				if isVisibleFrom(pass, scope, fieldName) {
+					return fieldName.Name, method, fieldName, true
+				}
+			}
+		}
+
+		if variableName, method, receiver, ok := ta.findSuiteReceiver(pass, enclosingRecv, goVersion); ok &&
+			isVisibleFrom(pass, scope, receiver) {
+			return variableName, method, receiver, true
+		}
+
+		if runner, ok := ta.ginkgoSpecRunner(pass, stack, i); ok {
+			return runner, defaultTempDirMethod, nil, true
+		}
 	}
 
-	return false
+	return "", "", nil, false
 }
 
-func checkSelectorExprTarget(selectorExpr *ast.SelectorExpr, pkgName string, selectorNames ...string) bool {
-	if expression, ok := selectorExpr.X.(*ast.Ident); ok {
-		return pkgName == expression.Name && find(selectorExpr.Sel.Name, selectorNames...)
+func isVisibleFrom(pass *analysis.Pass, scope *types.Scope, ident *ast.Ident) bool {
+	if ident.Name == "_" {
+		return false
 	}
 
-	return false
+	_, obj := scope.LookupParent(ident.Name, token.NoPos)
+
+	return obj != nil && obj == pass.TypesInfo.Defs[ident]
 }
 
-func getFirstFieldName(field *ast.Field) (string, bool) {
-	if len(field.Names) > 0 {
-		return field.Names[0].Name, true
+// checkFieldType returns the method of fieldType that provides a temporary
+// directory. The method is looked up in the context types table first,
+// otherwise any type whose method set includes a `TempDir() string` method
+// is accepted. The types of the testing package are skipped when goVersion
+// is older than the one that introduced their method, an empty goVersion
+// accepts them all.
+func (ta *ttempdirAnalyzer) checkFieldType(fieldType types.Type, goVersion string) (tempDirMethod string, found bool) {
+	if fieldType == nil {
+		return "", false
+	}
+
+	if typeName, method, ok := ta.lookupContextType(fieldType); ok && hasMethod(fieldType, method) {
+		return method, isGoVersionAtLeast(goVersion, contextGoVersions[typeName])
+	}
+
+	if isTempDirMethod(fieldType, defaultTempDirMethod) {
+		return defaultTempDirMethod, true
 	}
 
 	return "", false
 }
 
-func find(x string, ys ...string) bool {
-	for _, y := range ys {
-		if x == y {
-			return true
-		}
+// checkSuiteType returns the temp dir method of the testing type returned by
+// the `T()` method of typ, like the suites of github.com/stretchr/testify.
+func (ta *ttempdirAnalyzer) checkSuiteType(typ types.Type, goVersion string) (tempDirMethod string, found bool) {
+	signature, ok := lookupMethodSignature(typ, "T")
+	if !ok || signature.Params().Len() != 0 || signature.Results().Len() != 1 {
+		return "", false
+	}
+
+	return ta.checkFieldType(signature.Results().At(0).Type(), goVersion)
+}
+
+// isTempDirMethod reports whether the method set of typ includes a
+// `methodName() string` method.
+func isTempDirMethod(typ types.Type, methodName string) bool {
+	signature, ok := lookupMethodSignature(typ, methodName)
+
+	return ok &&
+		signature.Params().Len() == 0 &&
+		signature.Results().Len() == 1 &&
+		types.Identical(signature.Results().At(0).Type(), types.Typ[types.String])
+}
+
+func hasMethod(typ types.Type, methodName string) bool {
+	_, ok := lookupMethodSignature(typ, methodName)
+
+	return ok
+}
+
+func lookupMethodSignature(typ types.Type, methodName string) (*types.Signature, bool) {
+	if typ == nil {
+		return nil, false
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, methodName)
+
+	method, ok := obj.(*types.Func)
+	if !ok {
+		return nil, false
+	}
+
+	signature, ok := method.Type().(*types.Signature)
+
+	return signature, ok
+}
+
+func getFirstFieldIdent(field *ast.Field) (*ast.Ident, bool) {
+	if len(field.Names) > 0 {
+		return field.Names[0], true
 	}
 
-	return false
+	return nil, false
 }
@@ -1,6 +1,15 @@
 package analyzer_test
 
 import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/gostaticanalysis/testutil"
@@ -19,7 +28,7 @@ func TestAnalyzer(t *testing.T) {
 	}{
 		{
 			label:    "default flags",
-			patterns: []string{"a", "b", "c"},
+			patterns: []string{"a", "b", "c", "f", "g", "h", "j", "k/...", "l", "m", "q", "r", "s", "x", "aa", "ac/...", "ag/..."},
 		},
 		{
 			label: "flag all=true",
@@ -35,6 +44,73 @@ func TestAnalyzer(t *testing.T) {
 			},
 			patterns: []string{"e"},
 		},
+		{
+			label: "flag max-recursion-level=5",
+			flags: map[string]string{
+				analyzer.FlagMaxRecursionLevelName: "5",
+			},
+			patterns: []string{"i"},
+		},
+		{
+			label: "flag ginkgo=true",
+			flags: map[string]string{
+				analyzer.FlagGinkgoName: "true",
+			},
+			patterns: []string{"n"},
+		},
+		{
+			label: "flag context-type=gopkg.in/check.v1.C=MkDir,o/itest.Env=ScratchDir",
+			flags: map[string]string{
+				analyzer.FlagContextTypeName: "gopkg.in/check.v1.C=MkDir,o/itest.Env=ScratchDir",
+			},
+			patterns: []string{"o/..."},
+		},
+		{
+			label: "flag leak=true",
+			flags: map[string]string{
+				analyzer.FlagLeakName: "true",
+			},
+			patterns: []string{"t"},
+		},
+		{
+			label: "flag go-version=1.15",
+			flags: map[string]string{
+				analyzer.FlagGoVersionName: "1.15",
+				analyzer.FlagModernizeName: "true",
+			},
+			patterns: []string{"w"},
+		},
+		{
+			label: "flag generated=true exclude=*_fixture_test.go exclude-package=y/mocks/...",
+			flags: map[string]string{
+				analyzer.FlagGeneratedName:      "true",
+				analyzer.FlagExcludeName:        "*_fixture_test.go",
+				analyzer.FlagExcludePackageName: "y/mocks/...",
+			},
+			patterns: []string{"y/..."},
+		},
+		{
+			label: "flag include=z/checked_test.go include-package=z",
+			flags: map[string]string{
+				analyzer.FlagIncludeName:        "z/checked_test.go",
+				analyzer.FlagIncludePackageName: "z",
+			},
+			patterns: []string{"z/..."},
+		},
+		{
+			label: "flag func=af/fsutil.MakeScratch=use testfs.Scratch(t) instead",
+			flags: map[string]string{
+				analyzer.FlagFuncName: "af/fsutil.MakeScratch=use testfs.Scratch(t) instead",
+			},
+			patterns: []string{"af/..."},
+		},
+		{
+			label: "flag unused-directives=true",
+			flags: map[string]string{
+				analyzer.FlagUnusedDirectivesName: "true",
+			},
+			patterns: []string{"ab"},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -50,6 +126,256 @@ func TestAnalyzer(t *testing.T) {
 	}
 }
 
+// TestAnalyzerSuggestedFixes checks the suggested fixes against golden files.
+func TestAnalyzerSuggestedFixes(t *testing.T) {
+	testcases := []struct {
+		label    string
+		flags    map[string]string
+		patterns []string
+	}{
+		{
+			label:    "default flags",
+			patterns: []string{"p"},
+		},
+		{
+			label: "flag modernize=true",
+			flags: map[string]string{
+				analyzer.FlagModernizeName: "true",
+			},
+			patterns: []string{"u"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.label, func(t *testing.T) {
+			ttempdirAnalyze := analyzer.New()
+
+			setKV(t, ttempdirAnalyze, tc.flags)
+
+			analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), ttempdirAnalyze, tc.patterns...)
+		})
+	}
+}
+
+// TestAnalyzerGoVersion checks the suggestions against the Go version of
+// go.mod, which is only known in module mode.
+func TestAnalyzerGoVersion(t *testing.T) {
+	ttempdirAnalyze := analyzer.New()
+
+	setKV(t, ttempdirAnalyze, map[string]string{
+		analyzer.FlagModernizeName: "true",
+	})
+
+	analysistest.Run(t, filepath.Join(analysistest.TestData(), "src", "v"), ttempdirAnalyze, "./...")
+}
+
+// TestAnalyzerInvalidConfigFile checks that an invalid configuration file is
+// reported, and that the flags are used instead.
+func TestAnalyzerInvalidConfigFile(t *testing.T) {
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+
+	analysistest.Run(t, testdata, analyzer.New(), "ad")
+}
+
+// TestNewWithConfig checks the analyzer built from a Config.
+func TestNewWithConfig(t *testing.T) {
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+
+	ttempdirAnalyze, err := analyzer.NewWithConfig(analyzer.Config{
+		Functions: []analyzer.Function{
+			{Name: "ae/fsutil.MakeScratch"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	analysistest.Run(t, testdata, ttempdirAnalyze, "ae")
+}
+
+// TestNewWithConfigInvalid checks that an invalid Config is an error.
+func TestNewWithConfigInvalid(t *testing.T) {
+	testcases := []struct {
+		label string
+		cfg   analyzer.Config
+	}{
+		{
+			label: "go version",
+			cfg:   analyzer.Config{GoVersion: "one.twenty"},
+		},
+		{
+			label: "path glob",
+			cfg:   analyzer.Config{Exclude: []string{"mocks/[*.go"}},
+		},
+		{
+			label: "package pattern",
+			cfg:   analyzer.Config{IncludePackages: []string{"github.com/acme/*"}},
+		},
+		{
+			label: "context type",
+			cfg:   analyzer.Config{ContextTypes: map[string]string{"Env": "ScratchDir"}},
+		},
+		{
+			label: "function",
+			cfg:   analyzer.Config{Functions: []analyzer.Function{{Name: "MakeScratch"}}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.label, func(t *testing.T) {
+			if _, err := analyzer.NewWithConfig(tc.cfg); err == nil {
+				t.Errorf("expected an error for %+v", tc.cfg)
+			}
+		})
+	}
+}
+
+// TestAnalyzerCategories checks the category of the diagnostics.
+func TestAnalyzerCategories(t *testing.T) {
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+
+	for _, result := range analysistest.Run(t, testdata, analyzer.New(), "s") {
+		for _, diagnostic := range result.Diagnostics {
+			want := analyzer.CategoryStyle
+			if strings.HasSuffix(diagnostic.Message, "is never removed") {
+				want = analyzer.CategoryLeak
+			}
+
+			if diagnostic.Category != want {
+				t.Errorf("unexpected category %q for %q, want %q", diagnostic.Category, diagnostic.Message, want)
+			}
+		}
+	}
+}
+
+// TestAnalyzerRanges checks that the diagnostics span the call and point to
+// the testing parameter and to the removal of the temporary directory.
+func TestAnalyzerRanges(t *testing.T) {
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+
+	want := map[string][]string{
+		`os.MkdirTemp("", "assign")`: {"t is declared here", "the temporary directory is removed here"},
+		`os.MkdirTemp("", "if")`:     {"t is declared here", "the temporary directory is removed here"},
+		`os.TempDir()`:               {"tb is declared here"},
+	}
+
+	for _, result := range analysistest.Run(t, testdata, analyzer.New(), "ah") {
+		for _, diagnostic := range result.Diagnostics {
+			call := sourceText(t, result.Pass.Fset, diagnostic.Pos, diagnostic.End)
+
+			messages, ok := want[call]
+			if !ok {
+				t.Errorf("unexpected range %q for %q", call, diagnostic.Message)
+
+				continue
+			}
+
+			if len(diagnostic.Related) != len(messages) {
+				t.Fatalf("unexpected related information %+v for %q, want %q", diagnostic.Related, call, messages)
+			}
+
+			for index, related := range diagnostic.Related {
+				if related.Message != messages[index] {
+					t.Errorf("unexpected related message %q for %q, want %q", related.Message, call, messages[index])
+				}
+			}
+
+			delete(want, call)
+		}
+	}
+
+	for call := range want {
+		t.Errorf("no diagnostic for %q", call)
+	}
+}
+
+// TestAnalyzerSuggestedFixesAlone checks that each suggested fix compiles
+// when it is applied alone, like the quick fixes of an editor.
+func TestAnalyzerSuggestedFixesAlone(t *testing.T) {
+	ttempdirAnalyze := analyzer.New()
+
+	setKV(t, ttempdirAnalyze, map[string]string{analyzer.FlagModernizeName: "true"})
+
+	for _, result := range analysistest.Run(t, analysistest.TestData(), ttempdirAnalyze, "ai") {
+		for _, diagnostic := range result.Diagnostics {
+			for _, fix := range diagnostic.SuggestedFixes {
+				if err := typeCheckFixed(result.Pass, fix); err != nil {
+					t.Errorf("%s: %q does not compile: %v", result.Pass.Fset.Position(diagnostic.Pos), fix.Message, err)
+				}
+			}
+		}
+	}
+}
+
+// typeCheckFixed type checks the package of pass once fix is applied.
+func typeCheckFixed(pass *analysis.Pass, fix analysis.SuggestedFix) error {
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(pass.Files))
+
+	for _, file := range pass.Files {
+		tokFile := pass.Fset.File(file.Pos())
+
+		content, err := os.ReadFile(tokFile.Name())
+		if err != nil {
+			return err
+		}
+
+		fixed, err := parser.ParseFile(fset, tokFile.Name(), applyEdits(tokFile, content, fix.TextEdits), 0)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, fixed)
+	}
+
+	config := types.Config{Importer: importer.Default()}
+
+	_, err := config.Check(pass.Pkg.Path(), fset, files, nil)
+
+	return err
+}
+
+// applyEdits applies the edits of tokFile to its content.
+func applyEdits(tokFile *token.File, content []byte, edits []analysis.TextEdit) []byte {
+	var fileEdits []analysis.TextEdit
+
+	for _, edit := range edits {
+		if tokFile.Base() <= int(edit.Pos) && int(edit.Pos) <= tokFile.Base()+tokFile.Size() {
+			fileEdits = append(fileEdits, edit)
+		}
+	}
+
+	// the edits are applied from the end, so their offsets stay valid.
+	sort.Slice(fileEdits, func(i, j int) bool { return fileEdits[i].Pos > fileEdits[j].Pos })
+
+	for _, edit := range fileEdits {
+		end := edit.End
+		if !end.IsValid() {
+			end = edit.Pos
+		}
+
+		fixed := append([]byte(nil), content[:tokFile.Offset(edit.Pos)]...)
+		fixed = append(fixed, edit.NewText...)
+		content = append(fixed, content[tokFile.Offset(end):]...)
+	}
+
+	return content
+}
+
+// sourceText returns the source code between pos and end.
+func sourceText(t *testing.T, fset *token.FileSet, pos, end token.Pos) string {
+	t.Helper()
+
+	file := fset.File(pos)
+
+	content, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content[file.Offset(pos):file.Offset(end)])
+}
+
 func setKV(t *testing.T, instance *analysis.Analyzer, flags map[string]string) {
 	t.Helper()
 
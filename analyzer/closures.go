@@ -0,0 +1,148 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const anonymousFunctionName = "anonymous function"
+
+// nameClosures names the function literals of the function declarations of
+// the pass after the function that encloses them. The body of a subtest, like
+// `t.Run("empty key", func(t *testing.T) { ... })`, is named after the
+// subtest, like `TestStore/"empty key"`. The other function literals are
+// numbered in order of appearance, like the Go compiler does, e.g.
+// TestStore.func2 or TestStore.func2.1 for a function literal nested in it.
+func (ta *ttempdirAnalyzer) nameClosures(pass *analysis.Pass,
+	theInspector *inspector.Inspector,
+) map[*ast.FuncLit]string {
+	names := make(map[*ast.FuncLit]string)
+
+	theInspector.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(node ast.Node) {
+		function, _ := node.(*ast.FuncDecl)
+		if function.Body != nil {
+			ta.nameNestedClosures(pass.TypesInfo, function.Body, function.Name.Name, true, names)
+		}
+	})
+
+	return names
+}
+
+// nameNestedClosures names the function literals of body that are not
+// nested in another function literal, then the ones nested in them.
+// The outermost ones are numbered like funcN, the nested ones like N.
+func (ta *ttempdirAnalyzer) nameNestedClosures(info *types.Info,
+	body *ast.BlockStmt,
+	parentName string,
+	outermost bool,
+	names map[*ast.FuncLit]string,
+) {
+	subtests := make(map[*ast.FuncLit]string)
+	index := 0
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.CallExpr:
+			if closure, subtest, ok := ta.subtestName(info, node); ok {
+				subtests[closure] = subtest
+			}
+		case *ast.FuncLit:
+			index++
+
+			name := parentName + "." + strconv.Itoa(index)
+			if outermost {
+				name = parentName + ".func" + strconv.Itoa(index)
+			}
+
+			subtest, isSubtest := subtests[node]
+			if isSubtest {
+				name = parentName + "/" + subtest
+			}
+
+			names[node] = name
+
+			// the function literals of a subtest are numbered like outermost ones.
+			ta.nameNestedClosures(info, node.Body, name, isSubtest, names)
+
+			return false
+		}
+
+		return true
+	})
+}
+
+// subtestName returns the body of a subtest call, like `t.Run("empty key", func(t *testing.T) { ... })`,
+// and the name of the subtest, if it is a string literal, quoted, or a field, like `tc.name`.
+// The Run method must be the one of a testing type or of a suite, the other
+// ones are not subtests.
+func (ta *ttempdirAnalyzer) subtestName(info *types.Info, call *ast.CallExpr) (*ast.FuncLit, string, bool) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Run" || len(call.Args) != 2 {
+		return nil, "", false
+	}
+
+	closure, ok := call.Args[1].(*ast.FuncLit)
+	if !ok {
+		return nil, "", false
+	}
+
+	method, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || method.Type().(*types.Signature).Recv() == nil {
+		return nil, "", false
+	}
+
+	if !ta.isSubtestReceiver(info.TypeOf(selector.X)) {
+		return nil, "", false
+	}
+
+	switch name := ast.Unparen(call.Args[0]).(type) {
+	case *ast.BasicLit:
+		if name.Kind != token.STRING {
+			return nil, "", false
+		}
+
+		value, err := strconv.Unquote(name.Value)
+		if err != nil {
+			return nil, "", false
+		}
+
+		return closure, strconv.Quote(value), true
+	case *ast.SelectorExpr:
+		if selection, ok := info.Selections[name]; !ok || selection.Kind() != types.FieldVal {
+			return nil, "", false
+		}
+
+		return closure, types.ExprString(name), true
+	default:
+		return nil, "", false
+	}
+}
+
+// isSubtestReceiver reports whether typ is a testing type or a suite, whose
+// Run method runs a subtest.
+func (ta *ttempdirAnalyzer) isSubtestReceiver(typ types.Type) bool {
+	if _, ok := ta.checkFieldType(typ, ""); ok {
+		return true
+	}
+
+	_, ok := ta.checkSuiteType(typ, "")
+
+	return ok
+}
+
+// closureName returns the name of a function literal, or "anonymous
+// function" if it is not in a function declaration, like the value of a
+// package variable.
+func (state *passState) closureName(function *ast.FuncLit) string {
+	if name, ok := state.closureNames[function]; ok {
+		return name
+	}
+
+	return anonymousFunctionName
+}
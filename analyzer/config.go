@@ -0,0 +1,226 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+var errInvalidFunction = errors.New(
+	"invalid function, expected <package path>.<function> or (*<package path>.<type>).<method>")
+
+// Config is the configuration of the analyzer. Its zero value is the
+// default configuration. The flags of the analyzer and the configuration
+// files fill the same options.
+type Config struct {
+	// All checks all the functions of the test files, not only the ones with a testing parameter.
+	All bool
+	// MaxRecursionLevel is the max level of nested calls visited when checking an expression, 0 means no limit.
+	MaxRecursionLevel uint
+	// Ginkgo checks the closures passed to Ginkgo specs and setup nodes, like It or BeforeEach.
+	Ginkgo bool
+	// Leak checks non-test code for temporary directories and files that are not removed on every return path.
+	Leak bool
+	// Modernize checks all files for the deprecated ioutil.TempDir and ioutil.TempFile functions.
+	Modernize bool
+	// GoVersion is the Go version of the checked code, like 1.21, instead of the one of go.mod or of the
+	// //go:build constraints.
+	GoVersion string
+	// Generated also checks the generated files, with a `// Code generated ... DO NOT EDIT.` header.
+	Generated bool
+	// Include lists path globs, like internal/*_test.go, only the matching files are checked.
+	Include []string
+	// Exclude lists path globs, like mocks/*.go, the matching files are not checked.
+	Exclude []string
+	// IncludePackages lists package patterns, like github.com/acme/app/..., only the matching packages are checked.
+	IncludePackages []string
+	// ExcludePackages lists package patterns, like github.com/acme/app/mocks/..., the matching packages are not
+	// checked.
+	ExcludePackages []string
+	// UnusedDirectives reports the //nolint:ttempdir and //ttempdir:ignore directives that suppress nothing.
+	UnusedDirectives bool
+	// ContextTypes maps test context types, like gopkg.in/check.v1.C, to the method that returns a temporary
+	// directory, like MkDir. They are added to the types of the testing package.
+	ContextTypes map[string]string
+	// Functions lists the functions that create temporary directories, in addition to os.MkdirTemp,
+	// ioutil.TempDir and os.TempDir.
+	Functions []Function
+}
+
+// Function is a function that creates temporary directories.
+type Function struct {
+	// Name is the full name of the function, like github.com/acme/fsutil.MakeScratch,
+	// or of the method, like (*github.com/acme/fsutil.FS).TempDir.
+	Name string
+	// Message replaces the suggestion of the diagnostics, like "use testfs.Scratch(t) instead", if set.
+	Message string
+}
+
+// functionList is a flag.Value appending a function to a list, from its
+// name optionally followed by =<message>. Unlike the other lists, the values
+// are not comma separated, since the messages may hold commas.
+type functionList struct {
+	functions *[]Function
+}
+
+func newFunctionList(functions *[]Function) *functionList {
+	return &functionList{functions: functions}
+}
+
+// String implements flag.Value.
+func (l *functionList) String() string {
+	if l == nil || l.functions == nil {
+		return ""
+	}
+
+	names := make([]string, 0, len(*l.functions))
+	for _, function := range *l.functions {
+		names = append(names, function.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// Set implements flag.Value.
+func (l *functionList) Set(value string) error {
+	function, err := parseFunction(value)
+	if err != nil {
+		return err
+	}
+
+	*l.functions = append(*l.functions, function)
+
+	return nil
+}
+
+// parseFunction parses a function name optionally followed by =<message>,
+// like `github.com/acme/fsutil.MakeScratch=use testfs.Scratch(t) instead`.
+func parseFunction(value string) (Function, error) {
+	name, message, _ := strings.Cut(value, "=")
+
+	function := Function{
+		Name:    strings.TrimSpace(name),
+		Message: strings.TrimSpace(message),
+	}
+
+	if err := validateFunctionName(function.Name); err != nil {
+		return Function{}, err
+	}
+
+	return function, nil
+}
+
+func defaultConfig() Config {
+	return Config{
+		All:               defaultAll,
+		MaxRecursionLevel: defaultMaxRecursionLevel,
+		Ginkgo:            defaultGinkgo,
+		Leak:              defaultLeak,
+		Modernize:         defaultModernize,
+		Generated:         defaultGenerated,
+		UnusedDirectives:  defaultUnusedDirectives,
+	}
+}
+
+// normalized validates cfg and returns a copy of it, with the Go version
+// prefixed by go, that does not share its slices and maps.
+func (cfg Config) normalized() (Config, error) {
+	var goVersion goVersionFlag
+	if err := goVersion.Set(cfg.GoVersion); err != nil {
+		return Config{}, err
+	}
+
+	cfg.GoVersion = string(goVersion)
+
+	lists := []struct {
+		patterns *[]string
+		validate func(string) error
+	}{
+		{&cfg.Include, validateGlob},
+		{&cfg.Exclude, validateGlob},
+		{&cfg.IncludePackages, validatePackagePattern},
+		{&cfg.ExcludePackages, validatePackagePattern},
+	}
+
+	for _, list := range lists {
+		for _, pattern := range *list.patterns {
+			if err := list.validate(pattern); err != nil {
+				return Config{}, err
+			}
+		}
+
+		*list.patterns = append([]string(nil), *list.patterns...)
+	}
+
+	contextTypes := make(contextTypes, len(cfg.ContextTypes))
+
+	for typeName, method := range cfg.ContextTypes {
+		if err := contextTypes.Set(typeName + "=" + method); err != nil {
+			return Config{}, err
+		}
+	}
+
+	cfg.ContextTypes = contextTypes
+
+	for _, function := range cfg.Functions {
+		if err := validateFunctionName(function.Name); err != nil {
+			return Config{}, err
+		}
+	}
+
+	cfg.Functions = append([]Function(nil), cfg.Functions...)
+
+	return cfg, nil
+}
+
+// validateFunctionName checks that name is the full name of a function or a
+// method, as returned by types.Func.FullName.
+func validateFunctionName(name string) error {
+	if recv, method, ok := strings.Cut(strings.TrimPrefix(name, "("), ")."); ok && strings.HasPrefix(name, "(") {
+		if isQualifiedName(strings.TrimPrefix(recv, "*")) && token.IsIdentifier(method) {
+			return nil
+		}
+	} else if isQualifiedName(name) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q", errInvalidFunction, name)
+}
+
+// isQualifiedName reports whether name is an identifier qualified by a
+// package path, like github.com/acme/fsutil.MakeScratch.
+func isQualifiedName(name string) bool {
+	index := strings.LastIndex(name, ".")
+
+	return index > 0 && index > strings.LastIndex(name, "/") && token.IsIdentifier(name[index+1:])
+}
+
+// isExtraFunction reports whether function is one of the functions of the
+// configuration.
+func (ta *ttempdirAnalyzer) isExtraFunction(function *types.Func) bool {
+	_, found := ta.lookupFunction(function)
+
+	return found
+}
+
+// lookupFunction returns the function of the configuration matching the
+// callee function, by its full name. The instances of a generic function
+// match their origin.
+func (ta *ttempdirAnalyzer) lookupFunction(function *types.Func) (Function, bool) {
+	if len(ta.Functions) == 0 || function.Pkg() == nil {
+		return Function{}, false
+	}
+
+	pkgPath := function.Pkg().Path()
+	fullName := strings.Replace(function.Origin().FullName(), pkgPath, unvendoredPath(pkgPath), 1)
+
+	for _, extra := range ta.Functions {
+		if extra.Name == fullName {
+			return extra, true
+		}
+	}
+
+	return Function{}, false
+}
@@ -0,0 +1,291 @@
+package analyzer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileNames are the names of the configuration files, by order of
+// precedence when several ones are found in the same directory. YAML being a
+// superset of JSON, they are all decoded the same way.
+var configFileNames = []string{".ttempdir.yaml", ".ttempdir.yml", ".ttempdir.json"}
+
+// configFile is the content of a configuration file. Its keys are the names
+// of the flags. The unset keys keep the value of the parent directories, or
+// of the flags.
+type configFile struct {
+	All               *bool             `yaml:"all"`
+	MaxRecursionLevel *uint             `yaml:"max-recursion-level"`
+	Ginkgo            *bool             `yaml:"ginkgo"`
+	Leak              *bool             `yaml:"leak"`
+	Modernize         *bool             `yaml:"modernize"`
+	GoVersion         *string           `yaml:"go-version"`
+	Generated         *bool             `yaml:"generated"`
+	Include           []string          `yaml:"include"`
+	Exclude           []string          `yaml:"exclude"`
+	IncludePackages   []string          `yaml:"include-package"`
+	ExcludePackages   []string          `yaml:"exclude-package"`
+	UnusedDirectives  *bool             `yaml:"unused-directives"`
+	ContextTypes      map[string]string `yaml:"context-type"`
+	Functions         []configFunction  `yaml:"func"`
+}
+
+// configFunction is an entry of the func key, either a string like the
+// value of the flag, or a mapping with a name and a message.
+type configFunction struct {
+	Name    string `yaml:"name"`
+	Message string `yaml:"message"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (function *configFunction) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		function.Name, function.Message, _ = strings.Cut(node.Value, "=")
+
+		return nil
+	}
+
+	type plain configFunction
+
+	return node.Decode((*plain)(function))
+}
+
+// configCache holds the configuration files read so far, by directory, since
+// the packages of a directory tree share them.
+type configCache struct {
+	mu    sync.Mutex
+	files map[string]*configFile
+}
+
+func newConfigCache() *configCache {
+	return &configCache{files: make(map[string]*configFile)}
+}
+
+// load returns the configuration file of dir, or nil if there is none.
+func (cache *configCache) load(dir string) (*configFile, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if config, ok := cache.files[dir]; ok {
+		return config, nil
+	}
+
+	config, err := readConfigFile(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.files[dir] = config
+
+	return config, nil
+}
+
+func readConfigFile(dir string) (*configFile, error) {
+	for _, fileName := range configFileNames {
+		path := filepath.Join(dir, fileName)
+
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		var config configFile
+
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+
+		if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		if err := config.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		return &config, nil
+	}
+
+	return nil, nil
+}
+
+// validate checks the values of the configuration file by applying them to
+// an empty configuration, like the flags would.
+func (config *configFile) validate() error {
+	return config.apply(&Config{})
+}
+
+// apply overrides the options of cfg by the keys set in the configuration
+// file. The lists and the context types of cfg are replaced, not modified,
+// since they may be shared by other passes.
+func (config *configFile) apply(cfg *Config) error {
+	setBool(&cfg.All, config.All)
+	setBool(&cfg.Ginkgo, config.Ginkgo)
+	setBool(&cfg.Leak, config.Leak)
+	setBool(&cfg.Modernize, config.Modernize)
+	setBool(&cfg.Generated, config.Generated)
+	setBool(&cfg.UnusedDirectives, config.UnusedDirectives)
+
+	if config.MaxRecursionLevel != nil {
+		cfg.MaxRecursionLevel = *config.MaxRecursionLevel
+	}
+
+	if config.GoVersion != nil {
+		if err := (*goVersionFlag)(&cfg.GoVersion).Set(*config.GoVersion); err != nil {
+			return err
+		}
+	}
+
+	lists := []struct {
+		target   *[]string
+		patterns []string
+		newList  func(*[]string) *patternList
+	}{
+		{&cfg.Include, config.Include, newGlobList},
+		{&cfg.Exclude, config.Exclude, newGlobList},
+		{&cfg.IncludePackages, config.IncludePackages, newPackagePatternList},
+		{&cfg.ExcludePackages, config.ExcludePackages, newPackagePatternList},
+	}
+
+	for _, list := range lists {
+		if list.patterns == nil {
+			continue
+		}
+
+		var patterns []string
+		if err := list.newList(&patterns).Set(strings.Join(list.patterns, ",")); err != nil {
+			return err
+		}
+
+		*list.target = patterns
+	}
+
+	if config.Functions != nil {
+		functions := make([]Function, 0, len(config.Functions))
+
+		for _, entry := range config.Functions {
+			function, err := parseFunction(entry.Name)
+			if err != nil {
+				return err
+			}
+
+			function.Message = strings.TrimSpace(entry.Message)
+			functions = append(functions, function)
+		}
+
+		cfg.Functions = functions
+	}
+
+	if config.ContextTypes != nil {
+		merged := make(contextTypes, len(cfg.ContextTypes)+len(config.ContextTypes))
+		for typeName, method := range cfg.ContextTypes {
+			merged[typeName] = method
+		}
+
+		if err := merged.Set(config.contextTypeEntries()); err != nil {
+			return err
+		}
+
+		cfg.ContextTypes = merged
+	}
+
+	return nil
+}
+
+func (config *configFile) contextTypeEntries() string {
+	entries := make([]string, 0, len(config.ContextTypes))
+
+	for typeName, method := range config.ContextTypes {
+		entries = append(entries, typeName+"="+method)
+	}
+
+	sort.Strings(entries)
+
+	return strings.Join(entries, ",")
+}
+
+func setBool(target, value *bool) {
+	if value != nil {
+		*target = *value
+	}
+}
+
+// reportConfigError reports the error of an invalid configuration file on
+// the package clause of the first file of the pass.
+func reportConfigError(pass *analysis.Pass, err error) {
+	file := pass.Files[0]
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      file.Package,
+		End:      file.Name.End(),
+		Category: CategoryConfig,
+		Message:  "invalid configuration file " + err.Error() + ", the flags are used instead",
+	})
+}
+
+// forPass returns the analyzer to use for the package of the pass, with the
+// options of the configuration files found in its directory and in the
+// parent ones applied on top of the flags. The files of the nested
+// directories take precedence.
+func (ta *ttempdirAnalyzer) forPass(pass *analysis.Pass) (*ttempdirAnalyzer, error) {
+	if len(pass.Files) == 0 {
+		return ta, nil
+	}
+
+	tokFile := pass.Fset.File(pass.Files[0].Pos())
+	if tokFile == nil {
+		return ta, nil
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(tokFile.Name()))
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []*configFile
+
+	for {
+		config, err := ta.configs.load(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		if config != nil {
+			configs = append(configs, config)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+
+		dir = parent
+	}
+
+	if len(configs) == 0 {
+		return ta, nil
+	}
+
+	instance := *ta
+
+	for index := len(configs) - 1; index >= 0; index-- {
+		if err := configs[index].apply(&instance.Config); err != nil {
+			return nil, err
+		}
+	}
+
+	return &instance, nil
+}
@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+var errInvalidContextType = errors.New("invalid context type, expected <package path>.<type>=<method>")
+
+// contextTypes maps fully qualified type names, like testing.T, to the
+// method that returns a temporary directory for that type.
+type contextTypes map[string]string
+
+// defaultContextTypes are the types of the testing package.
+var defaultContextTypes = contextTypes{
+	"testing.T":  defaultTempDirMethod,
+	"testing.B":  defaultTempDirMethod,
+	"testing.F":  defaultTempDirMethod,
+	"testing.TB": defaultTempDirMethod,
+}
+
+// String implements flag.Value.
+func (c contextTypes) String() string {
+	entries := make([]string, 0, len(c))
+
+	for typeName, method := range c {
+		entries = append(entries, typeName+"="+method)
+	}
+
+	sort.Strings(entries)
+
+	return strings.Join(entries, ",")
+}
+
+// Set implements flag.Value.
+// Accepts a comma separated list of <package path>.<type>=<method> entries.
+func (c contextTypes) Set(value string) error {
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		typeName, method, ok := strings.Cut(entry, "=")
+		if !ok || !strings.Contains(typeName, ".") || !token.IsIdentifier(method) {
+			return fmt.Errorf("%w: %q", errInvalidContextType, entry)
+		}
+
+		c[strings.TrimPrefix(typeName, "*")] = method
+	}
+
+	return nil
+}
+
+// lookupContextType returns the name and the temp dir method of typ, or of
+// the type it points to. The context types of the configuration take
+// precedence over the default ones.
+func (ta *ttempdirAnalyzer) lookupContextType(typ types.Type) (typeName, method string, found bool) {
+	typeName, ok := contextTypeName(typ)
+	if !ok {
+		return "", "", false
+	}
+
+	if method, ok := ta.ContextTypes[typeName]; ok {
+		return typeName, method, true
+	}
+
+	method, found = defaultContextTypes[typeName]
+
+	return typeName, method, found
+}
+
+// contextTypeName returns the fully qualified name of typ, or of the type it
+// points to, like testing.T.
+func contextTypeName(typ types.Type) (string, bool) {
+	if pointer, ok := types.Unalias(typ).(*types.Pointer); ok {
+		typ = pointer.Elem()
+	}
+
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return "", false
+	}
+
+	obj := named.Origin().Obj()
+	if obj.Pkg() == nil {
+		return "", false
+	}
+
+	return unvendoredPath(obj.Pkg().Path()) + "." + obj.Name(), true
+}
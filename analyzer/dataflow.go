@@ -0,0 +1,226 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// pathJoinFunctions are the functions whose result is a path under their
+// arguments.
+var pathJoinFunctions = []string{"path/filepath.Join", "path.Join"}
+
+// valueIndex maps the local variables of a package to the expressions
+// assigned to them. It supports a simple dataflow analysis that follows
+// the values of variables, like `dir := os.TempDir()`, but not the
+// ones of fields, globals or function results.
+type valueIndex map[types.Object][]ast.Expr
+
+func newValueIndex(pass *analysis.Pass, theInspector *inspector.Inspector) valueIndex {
+	values := make(valueIndex)
+
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+	}
+
+	theInspector.Preorder(nodeFilter, func(node ast.Node) {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			values.addAll(pass.TypesInfo, node.Lhs, node.Rhs)
+		case *ast.ValueSpec:
+			names := make([]ast.Expr, 0, len(node.Names))
+			for _, name := range node.Names {
+				names = append(names, name)
+			}
+
+			values.addAll(pass.TypesInfo, names, node.Values)
+		}
+	})
+
+	return values
+}
+
+// addAll records the values assigned to the variables. When a single call
+// returns several values, only the first variable is recorded, like the
+// path returned by `os.MkdirTemp`.
+func (values valueIndex) addAll(info *types.Info, lhs, rhs []ast.Expr) {
+	switch {
+	case len(lhs) == len(rhs):
+		for index := range lhs {
+			values.add(info, lhs[index], rhs[index])
+		}
+	case len(rhs) == 1 && len(lhs) > 0:
+		values.add(info, lhs[0], rhs[0])
+	}
+}
+
+func (values valueIndex) add(info *types.Info, lhs, rhs ast.Expr) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok {
+		return
+	}
+
+	variable, ok := info.ObjectOf(ident).(*types.Var)
+	if !ok || !isLocalVariable(variable) {
+		return
+	}
+
+	values[variable] = append(values[variable], rhs)
+}
+
+func isLocalVariable(variable *types.Var) bool {
+	return !variable.IsField() &&
+		variable.Pkg() != nil &&
+		variable.Parent() != nil &&
+		variable.Parent() != variable.Pkg().Scope() &&
+		variable.Parent() != types.Universe
+}
+
+// derivesFrom reports whether the value of expr comes from a call for which
+// match returns true, directly, through the arguments of the calls in expr
+// or through the values assigned to the local variables it uses.
+func (values valueIndex) derivesFrom(info *types.Info,
+	expr ast.Expr,
+	match func(*ast.CallExpr) bool,
+) bool {
+	return values.derives(info, expr, match, make(map[types.Object]bool))
+}
+
+func (values valueIndex) derives(info *types.Info,
+	expr ast.Expr,
+	match func(*ast.CallExpr) bool,
+	visited map[types.Object]bool,
+) bool {
+	found := false
+
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if found {
+			return false
+		}
+
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			found = match(node)
+		case *ast.Ident:
+			found = values.identDerives(info, node, match, visited)
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+func (values valueIndex) identDerives(info *types.Info,
+	ident *ast.Ident,
+	match func(*ast.CallExpr) bool,
+	visited map[types.Object]bool,
+) bool {
+	obj := info.Uses[ident]
+	if obj == nil || visited[obj] {
+		return false
+	}
+
+	visited[obj] = true
+
+	for _, value := range values[obj] {
+		if values.derives(info, value, match, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// carries reports whether the value of expr holds obj, directly, in a
+// composite literal or through the values assigned to the local variables
+// it uses. Values passed to function calls are not followed, except for
+// conversions and the path joining functions, like
+// `filepath.Join(dir, "out")`.
+func (values valueIndex) carries(info *types.Info, expr ast.Expr, obj types.Object) bool {
+	return values.holds(info, expr, func(expr ast.Expr) bool {
+		ident, ok := expr.(*ast.Ident)
+
+		return ok && info.Uses[ident] == obj
+	}, make(map[types.Object]bool))
+}
+
+// carriesResultOf reports whether the value of expr holds the result of a
+// call for which match returns true, like carries.
+func (values valueIndex) carriesResultOf(info *types.Info,
+	expr ast.Expr,
+	match func(*ast.CallExpr) bool,
+) bool {
+	return values.holds(info, expr, func(expr ast.Expr) bool {
+		call, ok := expr.(*ast.CallExpr)
+
+		return ok && match(call)
+	}, make(map[types.Object]bool))
+}
+
+func (values valueIndex) holds(info *types.Info,
+	expr ast.Expr,
+	match func(ast.Expr) bool,
+	visited map[types.Object]bool,
+) bool {
+	found := false
+
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if found {
+			return false
+		}
+
+		if expr, ok := node.(ast.Expr); ok && match(expr) {
+			found = true
+
+			return false
+		}
+
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			return info.Types[node.Fun].IsType() || isCallTo(info, node, pathJoinFunctions...)
+		case *ast.Ident:
+			found = values.identHolds(info, node, match, visited)
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+func (values valueIndex) identHolds(info *types.Info,
+	ident *ast.Ident,
+	match func(ast.Expr) bool,
+	visited map[types.Object]bool,
+) bool {
+	used := info.Uses[ident]
+	if used == nil || visited[used] {
+		return false
+	}
+
+	visited[used] = true
+
+	for _, value := range values[used] {
+		if values.holds(info, value, match, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// callMatcher returns a function that reports whether a call calls one of
+// the functions, given by their full name.
+func callMatcher(info *types.Info, functionNames ...string) func(*ast.CallExpr) bool {
+	return func(call *ast.CallExpr) bool {
+		return isCallTo(info, call, functionNames...)
+	}
+}
@@ -0,0 +1,273 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	ignoreDirective = "//ttempdir:ignore"
+	nolintDirective = "//nolint"
+)
+
+// directive is a comment that suppresses the diagnostics of a line, of the
+// statement or declaration that follows it, or of a whole function, like
+// `//ttempdir:ignore reason="asserts global TMPDIR"` or `//nolint:ttempdir // asserts global TMPDIR`.
+type directive struct {
+	comment *ast.Comment
+	name    string
+	// from and to delimit the suppressed range.
+	from, to token.Pos
+	// owned is false for the directives that are not specific to this
+	// linter, like a bare `//nolint`, which are never reported.
+	owned     bool
+	hasReason bool
+	used      bool
+}
+
+type directives []*directive
+
+// parseDirectives returns the directives of the files of the pass, except
+// the skipped ones.
+func parseDirectives(pass *analysis.Pass, skippedFiles map[*token.File]bool) directives {
+	var all directives
+
+	for _, file := range pass.Files {
+		tokFile := pass.Fset.File(file.Pos())
+		if tokFile == nil || skippedFiles[tokFile] {
+			continue
+		}
+
+		var lines *fileLines
+
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				dir, ok := parseDirective(comment)
+				if !ok {
+					continue
+				}
+
+				if lines == nil {
+					lines = newFileLines(tokFile, file)
+				}
+
+				dir.from, dir.to = lines.suppressedRange(group, comment)
+				all = append(all, dir)
+			}
+		}
+	}
+
+	return all
+}
+
+// parseDirective parses the directives specific to this linter, as well as
+// the bare `//nolint` directive which applies to every linter.
+func parseDirective(comment *ast.Comment) (*directive, bool) {
+	text := comment.Text
+
+	if rest, ok := strings.CutPrefix(text, ignoreDirective); ok && (rest == "" || rest[0] == ' ') {
+		return &directive{
+			comment:   comment,
+			name:      ignoreDirective,
+			owned:     true,
+			hasReason: hasIgnoreReason(rest),
+		}, true
+	}
+
+	rest, ok := strings.CutPrefix(text, nolintDirective)
+	if !ok {
+		return nil, false
+	}
+
+	linters, reason, _ := strings.Cut(rest, "//")
+	linters = strings.TrimSpace(linters)
+
+	if linters == "" {
+		return &directive{comment: comment, name: nolintDirective}, true
+	}
+
+	names, ok := strings.CutPrefix(linters, ":")
+	if !ok {
+		return nil, false
+	}
+
+	for _, linter := range strings.Split(names, ",") {
+		if strings.TrimSpace(linter) == name {
+			return &directive{
+				comment:   comment,
+				name:      nolintDirective + ":" + name,
+				owned:     true,
+				hasReason: strings.TrimSpace(reason) != "",
+			}, true
+		}
+	}
+
+	return nil, false
+}
+
+// hasIgnoreReason reports whether the arguments of an ignore directive hold
+// a non-empty reason, like ` reason="asserts global TMPDIR"`.
+func hasIgnoreReason(args string) bool {
+	quoted, ok := strings.CutPrefix(strings.TrimSpace(args), "reason=")
+	if !ok {
+		return false
+	}
+
+	quoted, err := strconv.QuotedPrefix(quoted)
+	if err != nil {
+		return false
+	}
+
+	reason, err := strconv.Unquote(quoted)
+
+	return err == nil && strings.TrimSpace(reason) != ""
+}
+
+// fileLines indexes the code of a file by line.
+type fileLines struct {
+	tokFile *token.File
+	// starts maps the lines to the outermost statement or declaration that starts on them.
+	starts map[int]ast.Node
+	// code maps the lines to the position of their first token.
+	code map[int]token.Pos
+}
+
+func newFileLines(tokFile *token.File, file *ast.File) *fileLines {
+	lines := &fileLines{
+		tokFile: tokFile,
+		starts:  make(map[int]ast.Node),
+		code:    make(map[int]token.Pos),
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		if node == nil {
+			return false
+		}
+
+		switch node.(type) {
+		case *ast.CommentGroup, *ast.Comment:
+			return false
+		case ast.Stmt, ast.Decl, ast.Spec:
+			if _, ok := lines.starts[lines.line(node.Pos())]; !ok {
+				lines.starts[lines.line(node.Pos())] = node
+			}
+		}
+
+		// the closing token of a node, like `)` or `}`, may be alone on its line.
+		lines.addCode(node.Pos())
+		lines.addCode(node.End() - 1)
+
+		return true
+	})
+
+	return lines
+}
+
+func (lines *fileLines) line(pos token.Pos) int {
+	return lines.tokFile.PositionFor(pos, false).Line
+}
+
+func (lines *fileLines) addCode(pos token.Pos) {
+	line := lines.line(pos)
+	if first, ok := lines.code[line]; !ok || pos < first {
+		lines.code[line] = pos
+	}
+}
+
+// suppressedRange returns the range suppressed by comment. A comment that
+// follows some code on its line suppresses that line. Otherwise the comment
+// group suppresses the statement or declaration that starts on the next
+// line, like a whole function, or just that line if there is none.
+func (lines *fileLines) suppressedRange(group *ast.CommentGroup, comment *ast.Comment) (token.Pos, token.Pos) {
+	line := lines.line(comment.Pos())
+	if first, ok := lines.code[line]; !ok || first > comment.Pos() {
+		line = lines.line(group.End()) + 1
+
+		if node, ok := lines.starts[line]; ok {
+			return node.Pos(), node.End()
+		}
+	}
+
+	return lines.lineRange(line)
+}
+
+func (lines *fileLines) lineRange(line int) (token.Pos, token.Pos) {
+	tokFile := lines.tokFile
+	if line > tokFile.LineCount() {
+		return token.NoPos, token.NoPos
+	}
+
+	end := token.Pos(tokFile.Base() + tokFile.Size())
+	if line < tokFile.LineCount() {
+		end = tokFile.LineStart(line + 1)
+	}
+
+	return tokFile.LineStart(line), end
+}
+
+// suppresses reports whether a directive suppresses the diagnostic at pos,
+// and marks the matching directives as used.
+func (ds directives) suppresses(pos token.Pos) bool {
+	suppressed := false
+
+	for _, dir := range ds {
+		if dir.from <= pos && pos < dir.to {
+			dir.used = true
+			suppressed = true
+		}
+	}
+
+	return suppressed
+}
+
+// filter returns a copy of pass whose diagnostics are suppressed by the directives.
+func (ds directives) filter(pass *analysis.Pass) *analysis.Pass {
+	filtered := *pass
+	filtered.Report = func(diagnostic analysis.Diagnostic) {
+		if !ds.suppresses(diagnostic.Pos) {
+			pass.Report(diagnostic)
+		}
+	}
+
+	return &filtered
+}
+
+// report reports the directives of this linter without a reason, and the
+// unused ones if reportUnused is set.
+func (ds directives) report(pass *analysis.Pass, reportUnused bool) {
+	for _, dir := range ds {
+		if !dir.owned {
+			continue
+		}
+
+		if !dir.hasReason {
+			pass.Report(analysis.Diagnostic{
+				Pos:      dir.comment.Pos(),
+				End:      dir.comment.End(),
+				Category: CategoryDirective,
+				Message:  dir.name + " directive requires a reason, " + reasonExample(dir.name),
+			})
+		}
+
+		if reportUnused && !dir.used {
+			pass.Report(analysis.Diagnostic{
+				Pos:      dir.comment.Pos(),
+				End:      dir.comment.End(),
+				Category: CategoryDirective,
+				Message:  "unused " + dir.name + " directive",
+			})
+		}
+	}
+}
+
+func reasonExample(directiveName string) string {
+	if directiveName == ignoreDirective {
+		return `like ` + ignoreDirective + ` reason="..."`
+	}
+
+	return "like " + directiveName + " // reason"
+}
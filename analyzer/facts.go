@@ -0,0 +1,271 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/build"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// tempDirFact is exported for every function that creates or returns a
+// temporary directory, directly or through the functions it calls: the
+// temporary directory is returned to the caller, or created and never
+// removed.
+type tempDirFact struct {
+	// Chain lists the calls from the function to the temp dir function.
+	Chain []string
+	// Creates is false when the chain ends in os.TempDir, which creates
+	// nothing and only returns the default temporary directory.
+	Creates bool
+}
+
+func (*tempDirFact) AFact() {}
+
+func (f *tempDirFact) String() string {
+	if !f.Creates {
+		return "returns a path in the default temporary directory via " + strings.Join(f.Chain, " -> ")
+	}
+
+	return "creates temporary directory via " + strings.Join(f.Chain, " -> ")
+}
+
+// exportTempDirFacts exports a tempDirFact for each function of the package
+// that returns or leaks the temporary directory of a temp dir function.
+// Functions with a testing parameter and suite methods are skipped, since
+// they are reported on their own, as well as the TempDir methods of the
+// testing types, which are the expected replacement.
+func (ta *ttempdirAnalyzer) exportTempDirFacts(pass *analysis.Pass,
+	state *passState,
+	theInspector *inspector.Inspector,
+) {
+	var candidates []*ast.FuncDecl
+
+	theInspector.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(node ast.Node) {
+		function, _ := node.(*ast.FuncDecl)
+		if function.Body != nil &&
+			!ta.isTestFunction(pass, function) &&
+			!ta.isTestingTempDirMethod(pass.TypesInfo.Defs[function.Name]) {
+			candidates = append(candidates, function)
+		}
+	})
+
+	facts := make(map[*types.Func]*tempDirFact)
+
+	// iterate until no new fact is found, so the order of declarations does not matter.
+	for changed := true; changed; {
+		changed = false
+
+		for _, candidate := range candidates {
+			function, ok := pass.TypesInfo.Defs[candidate.Name].(*types.Func)
+			if !ok || facts[function] != nil {
+				continue
+			}
+
+			if found, ok := ta.findTempDirFact(pass, state, candidate, facts); ok {
+				facts[function] = &tempDirFact{
+					Chain:   append([]string{qualifiedFunctionName(function)}, found.Chain...),
+					Creates: found.Creates,
+				}
+				changed = true
+			}
+		}
+	}
+
+	for function, fact := range facts {
+		pass.ExportObjectFact(function, fact)
+	}
+}
+
+// findTempDirFact returns the fact of the first call of function whose
+// temporary directory is returned, or the one of the first temporary
+// directory created and never removed by function.
+func (ta *ttempdirAnalyzer) findTempDirFact(pass *analysis.Pass,
+	state *passState,
+	function *ast.FuncDecl,
+	facts map[*types.Func]*tempDirFact,
+) (found *tempDirFact, ok bool) {
+	info := pass.TypesInfo
+
+	isTempDirCall := func(call *ast.CallExpr) bool {
+		callee, isFunc := typeutil.Callee(info, call).(*types.Func)
+		if !isFunc {
+			return false
+		}
+
+		if fact, known := facts[callee]; known {
+			found = fact
+
+			return true
+		}
+
+		found, ok = ta.lookupTempDirFact(pass, callee)
+
+		return ok
+	}
+
+	for _, result := range returnedValues(info, state.values, function) {
+		if state.values.carriesResultOf(info, result, isTempDirCall) {
+			return found, true
+		}
+	}
+
+	found = nil
+
+	checker := leakChecker{
+		reporterBuilder: newReporterBuilder(pass, state, "", "", "", nil),
+		body:            function.Body,
+		leaked: func(call *ast.CallExpr, callee *types.Func) {
+			if found == nil && createsTempDir(callee) {
+				found = &tempDirFact{Chain: []string{qualifiedFunctionName(callee)}, Creates: true}
+			}
+		},
+	}
+
+	checker.checkLeaks(function.Body.List, nil)
+
+	return found, found != nil
+}
+
+// returnedValues returns the values returned by function, outside of its
+// function literals. The values of the named results are returned by the
+// return statements without results.
+func returnedValues(info *types.Info, values valueIndex, function *ast.FuncDecl) []ast.Expr {
+	var returned []ast.Expr
+
+	ast.Inspect(function.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			returned = append(returned, node.Results...)
+
+			if len(node.Results) == 0 && function.Type.Results != nil {
+				for _, field := range function.Type.Results.List {
+					for _, name := range field.Names {
+						returned = append(returned, values[info.Defs[name]]...)
+					}
+				}
+			}
+		}
+
+		return true
+	})
+
+	return returned
+}
+
+// lookupTempDirFact returns the fact of function: the temp dir functions
+// and the functions of the configuration, if any, have a fact of their own.
+func (ta *ttempdirAnalyzer) lookupTempDirFact(pass *analysis.Pass, function *types.Func) (*tempDirFact, bool) {
+	if isTempDirFunction(function) || ta.isExtraFunction(function) {
+		return &tempDirFact{
+			Chain:   []string{qualifiedFunctionName(function)},
+			Creates: !isTempDirFunction(function) || createsTempDir(function),
+		}, true
+	}
+
+	var fact tempDirFact
+
+	if pass.ImportObjectFact(function, &fact) {
+		return &fact, true
+	}
+
+	return nil, false
+}
+
+// isStandardLibrary reports whether the package of the pass belongs to the
+// standard library, which is neither checked nor tracked: packages like
+// net/http or mime/multipart manage their own temporary files.
+// Its import path has no dot in its first element and it is not part of a
+// module, unlike the packages of a module named like `module app`. Without
+// modules, the GOPATH packages may have such paths too, so the location of
+// the files is compared to GOROOT, without looking the package up.
+func isStandardLibrary(pass *analysis.Pass) bool {
+	if pass.Module != nil && pass.Module.Path != "" {
+		return false
+	}
+
+	firstElement, _, _ := strings.Cut(pass.Pkg.Path(), "/")
+	if strings.Contains(firstElement, ".") {
+		return false
+	}
+
+	if len(pass.Files) == 0 || build.Default.GOROOT == "" {
+		return true
+	}
+
+	filename := pass.Fset.Position(pass.Files[0].Package).Filename
+
+	return strings.HasPrefix(filename, filepath.Join(build.Default.GOROOT, "src")+string(filepath.Separator))
+}
+
+// isTestingTempDirMethod reports whether obj is the temp dir method of a testing type.
+func (ta *ttempdirAnalyzer) isTestingTempDirMethod(obj types.Object) bool {
+	function, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	signature, ok := function.Type().(*types.Signature)
+	if !ok || signature.Recv() == nil {
+		return false
+	}
+
+	method, ok := ta.checkFieldType(signature.Recv().Type(), "")
+
+	return ok && method == function.Name()
+}
+
+func isTempDirFunction(function *types.Func) bool {
+	switch function.FullName() {
+	case "io/ioutil.TempDir", "os.MkdirTemp", "os.TempDir":
+		return true
+	default:
+		return false
+	}
+}
+
+// createsTempDir reports whether function creates a new temporary directory,
+// unlike os.TempDir which only returns the default one.
+func createsTempDir(function *types.Func) bool {
+	return isTempDirFunction(function) && function.FullName() != "os.TempDir"
+}
+
+// isTempFileFunction reports whether function creates a temporary file
+// in the directory given as first argument.
+func isTempFileFunction(function *types.Func) bool {
+	switch function.FullName() {
+	case "io/ioutil.TempFile", "os.CreateTemp":
+		return true
+	default:
+		return false
+	}
+}
+
+// qualifiedFunctionName returns the function name qualified by its
+// package name and receiver type, if any. E.g. os.MkdirTemp or pkg.Type.Method.
+func qualifiedFunctionName(function *types.Func) string {
+	functionName := function.Name()
+
+	if signature, ok := function.Type().(*types.Signature); ok && signature.Recv() != nil {
+		recvType := signature.Recv().Type()
+		if pointer, ok := recvType.(*types.Pointer); ok {
+			recvType = pointer.Elem()
+		}
+
+		if named, ok := recvType.(*types.Named); ok {
+			functionName = named.Obj().Name() + "." + functionName
+		}
+	}
+
+	if function.Pkg() != nil {
+		functionName = function.Pkg().Name() + "." + functionName
+	}
+
+	return functionName
+}
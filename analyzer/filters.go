@@ -0,0 +1,171 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var (
+	errInvalidGlob           = errors.New("invalid path glob")
+	errInvalidPackagePattern = errors.New("invalid package pattern")
+)
+
+// patternList is a flag.Value filling a list of patterns from a comma
+// separated list. The flag can be repeated, each value is appended to the list.
+type patternList struct {
+	patterns *[]string
+	validate func(pattern string) error
+}
+
+// String implements flag.Value.
+func (l *patternList) String() string {
+	if l == nil || l.patterns == nil {
+		return ""
+	}
+
+	return strings.Join(*l.patterns, ",")
+}
+
+// Set implements flag.Value.
+func (l *patternList) Set(value string) error {
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		if err := l.validate(pattern); err != nil {
+			return err
+		}
+
+		*l.patterns = append(*l.patterns, pattern)
+	}
+
+	return nil
+}
+
+func newGlobList(globs *[]string) *patternList {
+	return &patternList{patterns: globs, validate: validateGlob}
+}
+
+func newPackagePatternList(patterns *[]string) *patternList {
+	return &patternList{patterns: patterns, validate: validatePackagePattern}
+}
+
+func validateGlob(glob string) error {
+	if _, err := path.Match(glob, ""); err != nil {
+		return fmt.Errorf("%w: %q", errInvalidGlob, glob)
+	}
+
+	return nil
+}
+
+func validatePackagePattern(pattern string) error {
+	if strings.ContainsAny(pattern, " \t*?[") {
+		return fmt.Errorf("%w: %q, expected a package path, optionally with ...", errInvalidPackagePattern, pattern)
+	}
+
+	return nil
+}
+
+// matchesGlob reports whether the slash separated fileName, or one of its
+// trailing parts, matches glob. E.g. `mocks/*.go` matches `/src/app/mocks/store.go`.
+func matchesGlob(glob, fileName string) bool {
+	for {
+		if matched, _ := path.Match(glob, fileName); matched {
+			return true
+		}
+
+		_, rest, found := strings.Cut(fileName, "/")
+		if !found {
+			return false
+		}
+
+		fileName = rest
+	}
+}
+
+func matchesAnyGlob(globs []string, fileName string) bool {
+	for _, glob := range globs {
+		if matchesGlob(glob, fileName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesPackagePattern reports whether pkgPath matches pattern, where `...`
+// matches any string, as in the patterns of the go command. E.g.
+// `github.com/acme/mocks/...` matches github.com/acme/mocks and its subpackages.
+func matchesPackagePattern(pattern, pkgPath string) bool {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `/\.\.\.`, `(/.*)?`)
+	expr = strings.ReplaceAll(expr, `\.\.\.`, `.*`)
+
+	matched, _ := regexp.MatchString("^"+expr+"$", unvendoredPath(pkgPath))
+
+	return matched
+}
+
+func matchesAnyPackagePattern(patterns []string, pkgPath string) bool {
+	for _, pattern := range patterns {
+		if matchesPackagePattern(pattern, pkgPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPackageChecked reports whether the package of the pass is selected by
+// the include-package and exclude-package options.
+func (ta *ttempdirAnalyzer) isPackageChecked(pass *analysis.Pass) bool {
+	pkgPath := pass.Pkg.Path()
+
+	if len(ta.IncludePackages) > 0 && !matchesAnyPackagePattern(ta.IncludePackages, pkgPath) {
+		return false
+	}
+
+	return !matchesAnyPackagePattern(ta.ExcludePackages, pkgPath)
+}
+
+// skippedFiles returns the files of the pass that are not checked: the
+// generated files, unless the generated option is set, and the files that
+// are not selected by the include and exclude options.
+func (ta *ttempdirAnalyzer) skippedFiles(pass *analysis.Pass) map[*token.File]bool {
+	skipped := make(map[*token.File]bool)
+
+	for _, file := range pass.Files {
+		tokFile := pass.Fset.File(file.Pos())
+		if tokFile == nil {
+			continue
+		}
+
+		if !ta.isFileChecked(file, filepath.ToSlash(tokFile.Name())) {
+			skipped[tokFile] = true
+		}
+	}
+
+	return skipped
+}
+
+func (ta *ttempdirAnalyzer) isFileChecked(file *ast.File, fileName string) bool {
+	if !ta.Generated && ast.IsGenerated(file) {
+		return false
+	}
+
+	if len(ta.Include) > 0 && !matchesAnyGlob(ta.Include, fileName) {
+		return false
+	}
+
+	return !matchesAnyGlob(ta.Exclude, fileName)
+}
@@ -0,0 +1,142 @@
+package analyzer
+
+import (
+	"go/ast"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// finding is the diagnostic of a call, before it is merged with the ones of
+// the calls nested in it, like os.TempDir in `os.MkdirTemp(os.TempDir(), "x")`.
+type finding struct {
+	call           *ast.CallExpr
+	functionName   string
+	message        string
+	leaks          bool
+	kind           string
+	related        []analysis.RelatedInformation
+	suggestedFixes []analysis.SuggestedFix
+	nested         []*finding
+}
+
+// contains reports whether the call of other is nested in the call of f.
+func (f *finding) contains(other *finding) bool {
+	return f.call.Pos() <= other.call.Pos() && other.call.End() <= f.call.End()
+}
+
+// flush reports the recorded findings, one per outermost call. The calls
+// nested in it are listed by its message, and their fixes are only used
+// when it has none, merged in a single fix.
+func (rb *passReporterBuilder) flush() {
+	findings := rb.findings
+	rb.findings = nil
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].call.Pos() != findings[j].call.Pos() {
+			return findings[i].call.Pos() < findings[j].call.Pos()
+		}
+
+		return findings[i].call.End() > findings[j].call.End()
+	})
+
+	var outermost []*finding
+
+	for _, current := range findings {
+		if last := len(outermost) - 1; last >= 0 && outermost[last].contains(current) {
+			outermost[last].nested = append(outermost[last].nested, current)
+
+			continue
+		}
+
+		outermost = append(outermost, current)
+	}
+
+	for _, current := range outermost {
+		rb.pass.Report(current.diagnostic())
+	}
+}
+
+func (f *finding) diagnostic() analysis.Diagnostic {
+	category, message := CategoryStyle, f.message
+
+	if len(f.nested) > 0 {
+		names := make([]string, 0, len(f.nested))
+		for _, nested := range f.nested {
+			names = append(names, nested.functionName+"()")
+		}
+
+		message += ", including the nested " + strings.Join(names, ", ")
+	}
+
+	if f.leaks {
+		category = CategoryLeak
+		message += ", the temporary " + f.kind + " is never removed"
+	}
+
+	return analysis.Diagnostic{
+		Pos:            f.call.Pos(),
+		End:            f.call.End(),
+		Category:       category,
+		Message:        message,
+		SuggestedFixes: f.fixes(),
+		Related:        f.related,
+	}
+}
+
+// fixes returns the fixes of the call or, if it has none, a single fix that
+// applies the ones of the nested calls, whose message names them. The fixes of a call nested in
+// another fixed call are skipped, since their edits overlap.
+func (f *finding) fixes() []analysis.SuggestedFix {
+	if len(f.suggestedFixes) > 0 || len(f.nested) == 0 {
+		return f.suggestedFixes
+	}
+
+	var (
+		merged   analysis.SuggestedFix
+		messages []string
+		fixed    []*finding
+	)
+
+	for _, nested := range f.nested {
+		if len(nested.suggestedFixes) == 0 || isContainedIn(fixed, nested) {
+			continue
+		}
+
+		messages = append(messages, nested.fixMessage())
+		merged.TextEdits = append(merged.TextEdits, nested.suggestedFixes[0].TextEdits...)
+
+		fixed = append(fixed, nested)
+	}
+
+	if len(merged.TextEdits) == 0 {
+		return nil
+	}
+
+	merged.Message = strings.Join(messages, ", ")
+
+	return []analysis.SuggestedFix{merged}
+}
+
+// fixMessage returns the message of the fix of a nested call, which only
+// rewrites that call, like "Replace the nested os.TempDir() with `t.TempDir()`".
+func (f *finding) fixMessage() string {
+	message := f.suggestedFixes[0].Message
+
+	if replacement, ok := strings.CutPrefix(message, "Replace with "); ok {
+		return "Replace the nested " + f.functionName + "() with " + replacement
+	}
+
+	return message
+}
+
+func isContainedIn(findings []*finding, other *finding) bool {
+	for _, current := range findings {
+		if current.contains(other) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,681 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// assignFix returns the suggested fix of an assignment whose value is a
+// call to a temp dir or temp file function, taking into account the
+// statements that follow it.
+func (rb *passReporterBuilder) assignFix(stmt *ast.AssignStmt,
+	following []ast.Stmt,
+) []analysis.SuggestedFix {
+	if len(stmt.Rhs) != 1 {
+		return nil
+	}
+
+	call, ok := stmt.Rhs[0].(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+
+	if isCallTo(rb.pass.TypesInfo, call, "os.CreateTemp", "io/ioutil.TempFile") {
+		return rb.createTempFix(call, stmt, following)
+	}
+
+	return rb.mkdirTempFix(stmt, following)
+}
+
+// mkdirTempFix returns the suggested fix that replaces an assignment like
+// `dir, err := os.MkdirTemp("", "x")` by `dir := t.TempDir()`. The error
+// check and the removal of dir that follow the assignment are deleted, the
+// imports are updated by importEdits.
+//
+// No fix is suggested when the rewritten code could not compile, e.g. when
+// the error variable is used by other statements.
+func (rb *passReporterBuilder) mkdirTempFix(stmt *ast.AssignStmt,
+	following []ast.Stmt,
+) []analysis.SuggestedFix {
+	if !rb.canFix(stmt.Pos()) || len(stmt.Lhs) != 2 || len(stmt.Rhs) != 1 {
+		return nil
+	}
+
+	info := rb.pass.TypesInfo
+
+	call, ok := stmt.Rhs[0].(*ast.CallExpr)
+	if !ok || !isCallTo(info, call, "os.MkdirTemp", "io/ioutil.TempDir") {
+		return nil
+	}
+
+	dirIdent, ok := stmt.Lhs[0].(*ast.Ident)
+	if !ok || dirIdent.Name == "_" {
+		return nil
+	}
+
+	errIdent, ok := stmt.Lhs[1].(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	tok := token.ASSIGN
+	if stmt.Tok == token.DEFINE && info.Defs[dirIdent] != nil {
+		tok = token.DEFINE
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     stmt.Pos(),
+		End:     stmt.End(),
+		NewText: []byte(dirIdent.Name + " " + tok.String() + " " + rb.TempDirCall()),
+	}}
+
+	file := rb.pass.Fset.File(stmt.Pos())
+	previous := ast.Stmt(stmt)
+
+	if errIdent.Name != "_" {
+		// an error declared before the assignment would keep its previous value.
+		errObj := info.Defs[errIdent]
+		if errObj == nil {
+			return nil
+		}
+
+		if len(following) > 0 && isErrCheck(info, following[0], errObj) {
+			edits = append(edits, deleteLines(file, previous, following[0]))
+			previous, following = following[0], following[1:]
+		}
+
+		if !isUnusedUntilRedeclared(info, following, errObj) {
+			return nil
+		}
+	}
+
+	if index, ok := findCleanup(info, following, "os.RemoveAll", info.ObjectOf(dirIdent)); ok {
+		if index > 0 {
+			previous = following[index-1]
+		}
+
+		edits = append(edits, deleteLines(file, previous, following[index]))
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   "Replace with `" + rb.TempDirCall() + "`",
+		TextEdits: edits,
+	}}
+}
+
+// tempDirFix returns the suggested fix that replaces a call like
+// `os.TempDir()` by `t.TempDir()`. No fix is suggested for the path of a
+// removal, like `os.RemoveAll(os.TempDir())`, which would create a temporary
+// directory only to remove it.
+func (rb *passReporterBuilder) tempDirFix(call *ast.CallExpr) []analysis.SuggestedFix {
+	if !rb.canFix(call.Pos()) || !isCallTo(rb.pass.TypesInfo, call, "os.TempDir") || rb.isRemovedPath(call) {
+		return nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   "Replace with `" + rb.TempDirCall() + "`",
+		TextEdits: []analysis.TextEdit{{Pos: call.Pos(), End: call.End(), NewText: []byte(rb.TempDirCall())}},
+	}}
+}
+
+// createTempFix returns the suggested fix that creates the temporary file
+// of call in the testing temp dir, like `os.CreateTemp(t.TempDir(), "x")`.
+// The calls to ioutil.TempFile are kept before Go 1.16.
+// If the call is the value of stmt, the removal of the file that follows
+// stmt is deleted, unless nothing else uses the file.
+func (rb *passReporterBuilder) createTempFix(call *ast.CallExpr,
+	stmt *ast.AssignStmt,
+	following []ast.Stmt,
+) []analysis.SuggestedFix {
+	info := rb.pass.TypesInfo
+
+	if !rb.canFix(call.Pos()) || len(call.Args) != 2 || !isEmptyString(info, call.Args[0]) {
+		return nil
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     call.Args[0].Pos(),
+		End:     call.Args[0].End(),
+		NewText: []byte(rb.TempDirCall()),
+	}}
+
+	createTemp := types.ExprString(call.Fun)
+
+	if isCallTo(info, call, "io/ioutil.TempFile") && rb.supportsGoVersion(call.Pos(), goVersionMkdirTemp) {
+		createTemp = rb.osQualifiedName(call.Pos(), "CreateTemp")
+		edits = append(edits, rb.referToOS(call.Fun, createTemp))
+	}
+
+	if stmt != nil && len(stmt.Lhs) > 0 {
+		if fileIdent, ok := stmt.Lhs[0].(*ast.Ident); ok {
+			fileObj := info.ObjectOf(fileIdent)
+
+			// the removal is kept when it is the only use of the file, which
+			// would be declared and not used otherwise.
+			index, ok := findCleanup(info, following, "os.Remove", fileObj)
+			if ok && !isUnusedUntilRedeclared(info, slices.Delete(slices.Clone(following), index, index+1), fileObj) {
+				var previous ast.Node = stmt
+				if index > 0 {
+					previous = following[index-1]
+				}
+
+				edits = append(edits, deleteLines(rb.pass.Fset.File(stmt.Pos()), previous, following[index]))
+			}
+		}
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   "Replace with `" + createTemp + "(" + rb.TempDirCall() + ", ...)`",
+		TextEdits: edits,
+	}}
+}
+
+// modernizeFix returns the suggested fix that replaces a call to a
+// deprecated io/ioutil function by its os equivalent, like
+// `ioutil.TempDir("", "x")` by `os.MkdirTemp("", "x")`.
+func (rb *passReporterBuilder) modernizeFix(call *ast.CallExpr, replacement string) []analysis.SuggestedFix {
+	function := rb.osQualifiedName(call.Pos(), replacement)
+
+	return []analysis.SuggestedFix{{
+		Message:   "Replace with `" + function + "`",
+		TextEdits: []analysis.TextEdit{rb.referToOS(call.Fun, function)},
+	}}
+}
+
+// osQualifiedName returns the name of the function of the os package as
+// written in the file that contains pos, like os.CreateTemp.
+func (rb *passReporterBuilder) osQualifiedName(pos token.Pos, functionName string) string {
+	osName, osImported := rb.importedName(pos, "os")
+	if !osImported {
+		osName = "os"
+	}
+
+	return osName + "." + functionName
+}
+
+// referToOS returns the edit that replaces expr by the os qualified name,
+// recorded so the os import is kept or added by importEdits.
+func (rb *passReporterBuilder) referToOS(expr ast.Expr, osQualifiedName string) analysis.TextEdit {
+	rb.state.osEdits[expr.Pos()] = true
+
+	return analysis.TextEdit{Pos: expr.Pos(), End: expr.End(), NewText: []byte(osQualifiedName)}
+}
+
+// deleteLines deletes the lines of node, including its trailing comment,
+// when no other node shares them, otherwise just node is deleted.
+func deleteLines(file *token.File, previous, node ast.Node) analysis.TextEdit {
+	startLine := file.PositionFor(node.Pos(), false).Line
+	endLine := file.PositionFor(node.End(), false).Line
+
+	if file.PositionFor(previous.End(), false).Line == startLine || endLine >= file.LineCount() {
+		return analysis.TextEdit{Pos: node.Pos(), End: node.End()}
+	}
+
+	return analysis.TextEdit{Pos: file.LineStart(startLine), End: file.LineStart(endLine + 1)}
+}
+
+// isCallTo reports whether call calls one of the functions, given by their full name.
+func isCallTo(info *types.Info, call *ast.CallExpr, functionNames ...string) bool {
+	function, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok {
+		return false
+	}
+
+	for _, functionName := range functionNames {
+		if function.FullName() == functionName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isEmptyString(info *types.Info, expr ast.Expr) bool {
+	value := info.Types[expr].Value
+
+	return value != nil && value.Kind() == constant.String && constant.StringVal(value) == ""
+}
+
+// isErrCheck reports whether stmt is an `if err != nil { ... }` statement,
+// without init statement or else branch.
+func isErrCheck(info *types.Info, stmt ast.Stmt, errObj types.Object) bool {
+	ifStmt, ok := stmt.(*ast.IfStmt)
+	if !ok || ifStmt.Init != nil || ifStmt.Else != nil {
+		return false
+	}
+
+	return isNotNil(info, ifStmt.Cond, errObj)
+}
+
+// isNotNil reports whether cond is an `err != nil` condition.
+func isNotNil(info *types.Info, cond ast.Expr, errObj types.Object) bool {
+	return isNilComparison(info, cond, token.NEQ, errObj)
+}
+
+// isEqualNil reports whether cond is an `err == nil` condition.
+func isEqualNil(info *types.Info, cond ast.Expr, errObj types.Object) bool {
+	return isNilComparison(info, cond, token.EQL, errObj)
+}
+
+func isNilComparison(info *types.Info, cond ast.Expr, op token.Token, errObj types.Object) bool {
+	binaryExpr, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok || binaryExpr.Op != op {
+		return false
+	}
+
+	return isObject(info, binaryExpr.X, errObj) && isNil(info, binaryExpr.Y) ||
+		isNil(info, binaryExpr.X) && isObject(info, binaryExpr.Y, errObj)
+}
+
+func isObject(info *types.Info, expr ast.Expr, obj types.Object) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+
+	return ok && obj != nil && info.ObjectOf(ident) == obj
+}
+
+func isNil(info *types.Info, expr ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	_, ok = info.ObjectOf(ident).(*types.Nil)
+
+	return ok
+}
+
+// isUnusedUntilRedeclared reports whether obj is not used by the statements
+// before it is redeclared by a short variable declaration of the same block.
+func isUnusedUntilRedeclared(info *types.Info, stmts []ast.Stmt, obj types.Object) bool {
+	for _, stmt := range stmts {
+		if assignStmt, ok := stmt.(*ast.AssignStmt); ok && assignStmt.Tok == token.DEFINE {
+			for _, expr := range assignStmt.Rhs {
+				if usesObject(info, expr, obj) {
+					return false
+				}
+			}
+
+			for _, expr := range assignStmt.Lhs {
+				if isObject(info, expr, obj) {
+					return true
+				}
+			}
+		}
+
+		if usesObject(info, stmt, obj) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func usesObject(info *types.Info, node ast.Node, obj types.Object) bool {
+	found := false
+
+	ast.Inspect(node, func(node ast.Node) bool {
+		if ident, ok := node.(*ast.Ident); ok && info.Uses[ident] == obj {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// findRemovalLater returns the call that removes the path assigned to lhs
+// in the following statements, in a defer, in a t.Cleanup callback or
+// directly, like `os.RemoveAll(dir)` or `os.Remove(f.Name())`.
+func (rb *passReporterBuilder) findRemovalLater(lhs ast.Expr, following []ast.Stmt) (*ast.CallExpr, bool) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+
+	info := rb.pass.TypesInfo
+
+	obj := info.ObjectOf(ident)
+	if obj == nil {
+		return nil, false
+	}
+
+	for _, stmt := range following {
+		if removal, ok := findRemoval(info, stmt, obj); ok {
+			return removal, true
+		}
+	}
+
+	return nil, false
+}
+
+// isRemovedPath reports whether expr is the argument of a removal, like
+// `os.RemoveAll(expr)`.
+func (rb *passReporterBuilder) isRemovedPath(expr ast.Expr) bool {
+	file := fileOf(rb.pass, expr.Pos())
+	if file == nil {
+		return false
+	}
+
+	path, _ := astutil.PathEnclosingInterval(file, expr.Pos(), expr.End())
+	if len(path) < 2 {
+		return false
+	}
+
+	for _, node := range path[1:] {
+		if _, ok := node.(*ast.ParenExpr); ok {
+			continue
+		}
+
+		call, ok := node.(*ast.CallExpr)
+
+		return ok && isCallTo(rb.pass.TypesInfo, call, "os.Remove", "os.RemoveAll")
+	}
+
+	return false
+}
+
+// removesPath reports whether node removes the path stored in obj, like
+// `os.RemoveAll(dir)` or `os.Remove(f.Name())`.
+func removesPath(info *types.Info, node ast.Node, obj types.Object) bool {
+	_, removes := findRemoval(info, node, obj)
+
+	return removes
+}
+
+// findRemoval returns the first call in node that removes the path stored in obj.
+func findRemoval(info *types.Info, node ast.Node, obj types.Object) (removal *ast.CallExpr, found bool) {
+	ast.Inspect(node, func(node ast.Node) bool {
+		if expr, ok := node.(ast.Expr); ok &&
+			(isRemoveCall(info, expr, "os.RemoveAll", obj) || isRemoveCall(info, expr, "os.Remove", obj)) {
+			removal, found = expr.(*ast.CallExpr)
+		}
+
+		return !found
+	})
+
+	return removal, found
+}
+
+// findCleanup returns the index of the first statement that removes the
+// path stored in obj, like `defer os.RemoveAll(dir)` or
+// `t.Cleanup(func() { os.RemoveAll(dir) })`.
+func findCleanup(info *types.Info, stmts []ast.Stmt, removeFunction string, obj types.Object) (int, bool) {
+	if obj == nil {
+		return 0, false
+	}
+
+	for index, stmt := range stmts {
+		if isCleanup(info, stmt, removeFunction, obj) {
+			return index, true
+		}
+	}
+
+	return 0, false
+}
+
+func isCleanup(info *types.Info, stmt ast.Stmt, removeFunction string, obj types.Object) bool {
+	switch stmt := stmt.(type) {
+	case *ast.DeferStmt:
+		return isRemoveCall(info, stmt.Call, removeFunction, obj) ||
+			len(stmt.Call.Args) == 0 && isRemoveClosure(info, stmt.Call.Fun, removeFunction, obj)
+	case *ast.ExprStmt:
+		call, ok := stmt.X.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return false
+		}
+
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+
+		return ok && selector.Sel.Name == "Cleanup" && isRemoveClosure(info, call.Args[0], removeFunction, obj)
+	default:
+		return false
+	}
+}
+
+// isRemoveClosure reports whether expr is a function literal whose body only
+// removes the path stored in obj, like `func() { _ = os.RemoveAll(dir) }`.
+func isRemoveClosure(info *types.Info, expr ast.Expr, removeFunction string, obj types.Object) bool {
+	funcLit, ok := expr.(*ast.FuncLit)
+	if !ok || len(funcLit.Body.List) != 1 {
+		return false
+	}
+
+	switch stmt := funcLit.Body.List[0].(type) {
+	case *ast.ExprStmt:
+		return isRemoveCall(info, stmt.X, removeFunction, obj)
+	case *ast.AssignStmt:
+		return len(stmt.Lhs) == 1 && len(stmt.Rhs) == 1 &&
+			isBlank(stmt.Lhs[0]) && isRemoveCall(info, stmt.Rhs[0], removeFunction, obj)
+	default:
+		return false
+	}
+}
+
+func isRemoveCall(info *types.Info, expr ast.Expr, removeFunction string, obj types.Object) bool {
+	call, ok := expr.(*ast.CallExpr)
+
+	return ok && len(call.Args) == 1 &&
+		isCallTo(info, call, removeFunction) &&
+		isPathOf(info, call.Args[0], obj)
+}
+
+// isPathOf reports whether expr is obj itself or the name of the file
+// stored in obj, like `f.Name()`.
+func isPathOf(info *types.Info, expr ast.Expr, obj types.Object) bool {
+	if isObject(info, expr, obj) {
+		return true
+	}
+
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+
+	return ok && selector.Sel.Name == "Name" && isObject(info, selector.X, obj)
+}
+
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && ident.Name == "_"
+}
+
+// importSpecRef locates an import spec in its declaration.
+type importSpecRef struct {
+	genDecl *ast.GenDecl
+	index   int
+}
+
+func (ref importSpecRef) spec() *ast.ImportSpec {
+	importSpec, _ := ref.genDecl.Specs[ref.index].(*ast.ImportSpec)
+
+	return importSpec
+}
+
+// unusedImports returns the imports of the given packages that are not used
+// anymore once edits are applied to the file that contains pos.
+func (rb *passReporterBuilder) unusedImports(pos token.Pos,
+	edits []analysis.TextEdit,
+	importPaths ...string,
+) []importSpecRef {
+	file := fileOf(rb.pass, pos)
+	if file == nil {
+		return nil
+	}
+
+	var unused []importSpecRef
+
+	for _, ref := range importSpecs(file, importPaths...) {
+		pkgName := rb.importedPkgName(ref.spec())
+		if pkgName != nil && !rb.isPkgNameUsed(file, pkgName, edits) {
+			unused = append(unused, ref)
+		}
+	}
+
+	return unused
+}
+
+func importSpecs(file *ast.File, importPaths ...string) []importSpecRef {
+	var refs []importSpecRef
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+
+		for index, spec := range genDecl.Specs {
+			if importSpec, ok := spec.(*ast.ImportSpec); ok && isImportOf(importSpec, importPaths) {
+				refs = append(refs, importSpecRef{genDecl: genDecl, index: index})
+			}
+		}
+	}
+
+	return refs
+}
+
+// importedName returns the name used by the file that contains pos to
+// refer to the package importPath.
+func (rb *passReporterBuilder) importedName(pos token.Pos, importPath string) (string, bool) {
+	file := fileOf(rb.pass, pos)
+	if file == nil {
+		return "", false
+	}
+
+	for _, ref := range importSpecs(file, importPath) {
+		if pkgName := rb.importedPkgName(ref.spec()); pkgName != nil {
+			return pkgName.Name(), true
+		}
+	}
+
+	return "", false
+}
+
+// addImport returns the edit that imports importPath in the file that
+// contains pos, next to its first import declaration.
+func (rb *passReporterBuilder) addImport(pos token.Pos, importPath string) []analysis.TextEdit {
+	file := fileOf(rb.pass, pos)
+	if file == nil {
+		return nil
+	}
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+
+		if genDecl.Rparen.IsValid() {
+			tokFile := rb.pass.Fset.File(pos)
+			lineStart := tokFile.LineStart(tokFile.PositionFor(genDecl.Rparen, false).Line)
+
+			return []analysis.TextEdit{{
+				Pos:     lineStart,
+				End:     lineStart,
+				NewText: []byte("\t" + strconv.Quote(importPath) + "\n"),
+			}}
+		}
+
+		return []analysis.TextEdit{{
+			Pos:     genDecl.End(),
+			End:     genDecl.End(),
+			NewText: []byte("\nimport " + strconv.Quote(importPath)),
+		}}
+	}
+
+	return []analysis.TextEdit{{
+		Pos:     file.Name.End(),
+		End:     file.Name.End(),
+		NewText: []byte("\n\nimport " + strconv.Quote(importPath)),
+	}}
+}
+
+// fileOf returns the file of the pass that contains pos.
+func fileOf(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, file := range pass.Files {
+		if file.FileStart <= pos && pos < file.FileEnd {
+			return file
+		}
+	}
+
+	return nil
+}
+
+func isImportOf(importSpec *ast.ImportSpec, importPaths []string) bool {
+	for _, importPath := range importPaths {
+		if importSpec.Path.Value == `"`+importPath+`"` {
+			return true
+		}
+	}
+
+	return false
+}
+
+// importedPkgName returns the package name declared by importSpec, or nil
+// for blank and dot imports.
+func (rb *passReporterBuilder) importedPkgName(importSpec *ast.ImportSpec) *types.PkgName {
+	var obj types.Object
+	if importSpec.Name != nil {
+		obj = rb.pass.TypesInfo.Defs[importSpec.Name]
+	} else {
+		obj = rb.pass.TypesInfo.Implicits[importSpec]
+	}
+
+	pkgName, _ := obj.(*types.PkgName)
+
+	return pkgName
+}
+
+// isPkgNameUsed reports whether pkgName is used outside the edited ranges.
+func (rb *passReporterBuilder) isPkgNameUsed(file *ast.File,
+	pkgName *types.PkgName,
+	edits []analysis.TextEdit,
+) bool {
+	used := false
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		if ident, ok := node.(*ast.Ident); ok &&
+			rb.pass.TypesInfo.Uses[ident] == pkgName &&
+			!isEdited(ident.Pos(), edits) {
+			used = true
+		}
+
+		return !used
+	})
+
+	return used
+}
+
+func isEdited(pos token.Pos, edits []analysis.TextEdit) bool {
+	for _, edit := range edits {
+		if edit.Pos <= pos && pos < edit.End {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rb *passReporterBuilder) deleteImportSpec(genDecl *ast.GenDecl, index int) analysis.TextEdit {
+	if len(genDecl.Specs) == 1 {
+		return analysis.TextEdit{Pos: genDecl.Pos(), End: genDecl.End()}
+	}
+
+	var previous ast.Node = genDecl
+	if index > 0 {
+		previous = genDecl.Specs[index-1]
+	}
+
+	return deleteLines(rb.pass.Fset.File(genDecl.Pos()), previous, genDecl.Specs[index])
+}
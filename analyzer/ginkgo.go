@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const ginkgoPkgPathPrefix = "github.com/onsi/ginkgo"
+
+// ginkgoSpecRunner returns the `GinkgoT()` expression to use when the function
+// literal at stack[index] is passed to a Ginkgo node, like It or BeforeEach.
+func (ta *ttempdirAnalyzer) ginkgoSpecRunner(pass *analysis.Pass, stack []ast.Node, index int) (string, bool) {
+	if !ta.Ginkgo || index < 1 {
+		return "", false
+	}
+
+	funcLit, ok := stack[index].(*ast.FuncLit)
+	if !ok {
+		return "", false
+	}
+
+	callExpr, ok := stack[index-1].(*ast.CallExpr)
+	if !ok || callExpr.Fun == ast.Expr(funcLit) {
+		return "", false
+	}
+
+	function, ok := typeutil.Callee(pass.TypesInfo, callExpr).(*types.Func)
+	if !ok || !isGinkgoNode(function) {
+		return "", false
+	}
+
+	var qualifier string
+
+	if selectorExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+		if ident, ok := selectorExpr.X.(*ast.Ident); ok {
+			qualifier = ident.Name + "."
+		}
+	}
+
+	return qualifier + "GinkgoT()", true
+}
+
+// isGinkgoNode reports whether function is a Ginkgo DSL function whose
+// closure runs while a spec is running.
+func isGinkgoNode(function *types.Func) bool {
+	if function.Pkg() == nil || !strings.HasPrefix(unvendoredPath(function.Pkg().Path()), ginkgoPkgPathPrefix) {
+		return false
+	}
+
+	switch strings.TrimLeft(function.Name(), "FPX") {
+	case "It", "Specify",
+		"BeforeEach", "JustBeforeEach", "AfterEach", "JustAfterEach",
+		"BeforeAll", "AfterAll",
+		"BeforeSuite", "AfterSuite", "SynchronizedBeforeSuite", "SynchronizedAfterSuite",
+		"DeferCleanup", "DescribeTable":
+		return true
+	default:
+		return false
+	}
+}
+
+// unvendoredPath removes the vendor directory prefix from pkgPath, if any.
+func unvendoredPath(pkgPath string) string {
+	const vendor = "vendor/"
+
+	if i := strings.LastIndex(pkgPath, "/"+vendor); i >= 0 {
+		return pkgPath[i+len(vendor)+1:]
+	}
+
+	return strings.TrimPrefix(pkgPath, vendor)
+}
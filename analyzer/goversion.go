@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build/constraint"
+	"go/token"
+	"go/version"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Minimum Go versions of the functions suggested as replacement.
+const (
+	goVersionTempDir   = "go1.15" // testing.T.TempDir
+	goVersionFuzzing   = "go1.18" // testing.F
+	goVersionMkdirTemp = "go1.16" // os.MkdirTemp and os.CreateTemp
+)
+
+var errInvalidGoVersion = errors.New("invalid go version, expected a version like 1.21")
+
+// contextGoVersions maps the default context types to the Go version that
+// introduced their temp dir method.
+var contextGoVersions = map[string]string{
+	"testing.T":  goVersionTempDir,
+	"testing.B":  goVersionTempDir,
+	"testing.F":  goVersionFuzzing,
+	"testing.TB": goVersionTempDir,
+}
+
+// goVersionFlag is the Go version set on the command line, like go1.21.
+type goVersionFlag string
+
+// String implements flag.Value.
+func (v *goVersionFlag) String() string {
+	if v == nil {
+		return ""
+	}
+
+	return string(*v)
+}
+
+// Set implements flag.Value.
+// Accepts a version with or without the go prefix, like 1.21 or go1.21.
+func (v *goVersionFlag) Set(value string) error {
+	value = strings.TrimSpace(value)
+	if value != "" && !strings.HasPrefix(value, "go") {
+		value = "go" + value
+	}
+
+	if value != "" && !version.IsValid(value) {
+		return fmt.Errorf("%w: %q", errInvalidGoVersion, value)
+	}
+
+	*v = goVersionFlag(value)
+
+	return nil
+}
+
+// fileGoVersion returns the Go version of the file that contains pos. The
+// override, if any, takes precedence over the version of the module, raised
+// by the //go:build constraint of the file. An empty version means unknown.
+func fileGoVersion(pass *analysis.Pass, override string, pos token.Pos) string {
+	if override != "" {
+		return override
+	}
+
+	goVersion := pass.Pkg.GoVersion()
+	if pass.Module != nil && pass.Module.GoVersion != "" {
+		goVersion = "go" + strings.TrimPrefix(pass.Module.GoVersion, "go")
+	}
+
+	if file := fileOf(pass, pos); file != nil {
+		if buildVersion := buildConstraintGoVersion(file); version.Compare(buildVersion, goVersion) > 0 {
+			goVersion = buildVersion
+		}
+	}
+
+	return goVersion
+}
+
+// buildConstraintGoVersion returns the minimum Go version required by the
+// //go:build constraint of file, like go1.16 for `//go:build go1.16`, if any.
+func buildConstraintGoVersion(file *ast.File) string {
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+
+		for _, comment := range group.List {
+			if !constraint.IsGoBuild(comment.Text) {
+				continue
+			}
+
+			if expr, err := constraint.Parse(comment.Text); err == nil {
+				return constraint.GoVersion(expr)
+			}
+		}
+	}
+
+	return ""
+}
+
+// isGoVersionAtLeast reports whether goVersion is at least minimum. An
+// unknown version is assumed to be recent enough.
+func isGoVersionAtLeast(goVersion, minimum string) bool {
+	return goVersion == "" || minimum == "" || version.Compare(goVersion, minimum) >= 0
+}
@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// bufferReports returns a copy of pass whose diagnostics are recorded in
+// state instead of reported, see reportDiagnostics.
+func (state *passState) bufferReports(pass *analysis.Pass) *analysis.Pass {
+	buffered := *pass
+	buffered.Report = func(diagnostic analysis.Diagnostic) {
+		state.diagnostics = append(state.diagnostics, diagnostic)
+	}
+
+	return &buffered
+}
+
+// reportDiagnostics reports the diagnostics recorded by the pass. The
+// imports are updated by each fix on its own, as an editor applies a single
+// fix: the same import edit may be part of several fixes, `-fix` merges the
+// identical edits.
+func (rb *passReporterBuilder) reportDiagnostics() {
+	diagnostics := rb.state.diagnostics
+	rb.state.diagnostics = nil
+
+	for _, diagnostic := range diagnostics {
+		file := fileOf(rb.pass, diagnostic.Pos)
+		if file == nil || len(diagnostic.SuggestedFixes) == 0 {
+			rb.pass.Report(diagnostic)
+
+			continue
+		}
+
+		// the fixes may be shared, they are copied before being modified.
+		suggestedFixes := make([]analysis.SuggestedFix, 0, len(diagnostic.SuggestedFixes))
+
+		for _, fix := range diagnostic.SuggestedFixes {
+			if importEdits := rb.importEdits(file, fix); len(importEdits) > 0 {
+				fix.TextEdits = append(append([]analysis.TextEdit(nil), fix.TextEdits...), importEdits...)
+			}
+
+			suggestedFixes = append(suggestedFixes, fix)
+		}
+
+		diagnostic.SuggestedFixes = suggestedFixes
+
+		rb.pass.Report(diagnostic)
+	}
+}
+
+// importEdits returns the edits of the imports of file once fix is
+// applied: the imports of os and io/ioutil that are not used anymore are
+// deleted, and os is imported if the fix refers to it. An unused io/ioutil
+// import is replaced by it.
+func (rb *passReporterBuilder) importEdits(file *ast.File, fix analysis.SuggestedFix) []analysis.TextEdit {
+	refersToOS := false
+
+	for _, edit := range fix.TextEdits {
+		refersToOS = refersToOS || rb.state.osEdits[edit.Pos]
+	}
+
+	importPaths := []string{"io/ioutil"}
+	if !refersToOS {
+		importPaths = append(importPaths, "os")
+	}
+
+	_, osImported := rb.importedName(file.Pos(), "os")
+	importOS := refersToOS && !osImported
+
+	var importEdits []analysis.TextEdit
+
+	for _, unused := range rb.unusedImports(file.Pos(), fix.TextEdits, importPaths...) {
+		if spec := unused.spec(); importOS && isImportOf(spec, []string{"io/ioutil"}) {
+			importEdits = append(importEdits, analysis.TextEdit{Pos: spec.Pos(), End: spec.Path.End(), NewText: []byte(`"os"`)})
+			importOS = false
+
+			continue
+		}
+
+		importEdits = append(importEdits, rb.deleteImportSpec(unused.genDecl, unused.index))
+	}
+
+	if importOS {
+		importEdits = append(importEdits, rb.addImport(file.Pos(), "os")...)
+	}
+
+	return importEdits
+}
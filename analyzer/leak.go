@@ -0,0 +1,394 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// leakChecker looks for temporary directories and files created by non-test
+// functions that are neither removed on every return path nor handed over to
+// the caller.
+type leakChecker struct {
+	reporterBuilder *passReporterBuilder
+	body            *ast.BlockStmt
+	// leaked is called with the calls whose temporary directory or file leaks.
+	leaked func(call *ast.CallExpr, function *types.Func)
+	// discardedError, if set, is called with the calls whose error is discarded.
+	discardedError func(call *ast.CallExpr, function *types.Func)
+}
+
+// newLeakReporter returns a leakChecker that reports the leaks of body and
+// the discarded errors.
+func newLeakReporter(reporterBuilder *passReporterBuilder, body *ast.BlockStmt) *leakChecker {
+	return &leakChecker{
+		reporterBuilder: reporterBuilder,
+		body:            body,
+		leaked: func(call *ast.CallExpr, function *types.Func) {
+			reporterBuilder.ReportLeak(call, qualifiedFunctionName(function), isTempFileFunction(function))
+		},
+		discardedError: func(call *ast.CallExpr, function *types.Func) {
+			reporterBuilder.ReportDiscardedError(call, qualifiedFunctionName(function))
+		},
+	}
+}
+
+// checkLeaks checks the statements of a function body, following the nested
+// blocks. A creation is checked against the rest of its block and of the
+// enclosing blocks, up to the end of the function.
+func (lc *leakChecker) checkLeaks(stmts []ast.Stmt, continuation [][]ast.Stmt) {
+	for index, stmt := range stmts {
+		rest := append([][]ast.Stmt{stmts[index+1:]}, continuation...)
+
+		// the variables of an init statement are only visible in its statement.
+		if init := initStmt(stmt); init != nil {
+			lc.checkCreation(init, append([][]ast.Stmt{{stmt}}, rest...))
+		}
+
+		lc.checkCreation(stmt, rest)
+
+		for _, nested := range nestedStmtLists(stmt) {
+			lc.checkLeaks(nested, rest)
+		}
+	}
+}
+
+func (lc *leakChecker) checkCreation(stmt ast.Stmt, rest [][]ast.Stmt) {
+	info := lc.reporterBuilder.pass.TypesInfo
+
+	switch stmt := stmt.(type) {
+	case *ast.ExprStmt:
+		if call, function, ok := creationCall(info, stmt.X); ok {
+			lc.leaked(call, function)
+		}
+	case *ast.AssignStmt:
+		lc.checkAssignedCreation(stmt.Lhs, stmt.Rhs, rest)
+	case *ast.DeclStmt:
+		genDecl, ok := stmt.Decl.(*ast.GenDecl)
+		if !ok {
+			return
+		}
+
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+
+			lhs := make([]ast.Expr, 0, len(valueSpec.Names))
+			for _, name := range valueSpec.Names {
+				lhs = append(lhs, name)
+			}
+
+			lc.checkAssignedCreation(lhs, valueSpec.Values, rest)
+		}
+	}
+}
+
+// checkAssignedCreation checks the creation assigned to the path and error
+// variables of lhs, like `dir, err := os.MkdirTemp("", "x")` or
+// `var dir, err = os.MkdirTemp("", "x")`.
+func (lc *leakChecker) checkAssignedCreation(lhs, rhs []ast.Expr, rest [][]ast.Stmt) {
+	if len(rhs) != 1 || len(lhs) != 2 {
+		return
+	}
+
+	call, function, ok := creationCall(lc.reporterBuilder.pass.TypesInfo, rhs[0])
+	if !ok {
+		return
+	}
+
+	if isBlank(lhs[1]) && lc.discardedError != nil {
+		lc.discardedError(call, function)
+	}
+
+	if !lc.isHandled(lhs[0], lhs[1], rest) {
+		lc.leaked(call, function)
+	}
+}
+
+// creationCall returns the call of expr if it creates a temporary directory or file.
+func creationCall(info *types.Info, expr ast.Expr) (*ast.CallExpr, *types.Func, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return nil, nil, false
+	}
+
+	function, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || !createsTempDir(function) && !isTempFileFunction(function) {
+		return nil, nil, false
+	}
+
+	return call, function, true
+}
+
+// leakTarget holds the variables assigned by the creation of a temporary
+// directory or file.
+type leakTarget struct {
+	path types.Object
+	err  types.Object
+}
+
+// isHandled reports whether the path stored in lhs is returned to the
+// caller, stored out of the function or removed on every return path.
+// The paths where errLhs is not nil are skipped, since nothing was created.
+func (lc *leakChecker) isHandled(lhs, errLhs ast.Expr, rest [][]ast.Stmt) bool {
+	info := lc.reporterBuilder.pass.TypesInfo
+
+	ident, ok := lhs.(*ast.Ident)
+	if !ok {
+		// stored in a field or an element, out of the scope of this check.
+		return true
+	}
+
+	if ident.Name == "_" {
+		return false
+	}
+
+	obj := info.ObjectOf(ident)
+	if obj == nil {
+		return true
+	}
+
+	if lc.escapes(obj) {
+		return true
+	}
+
+	target := leakTarget{path: obj}
+	if errIdent, ok := errLhs.(*ast.Ident); ok {
+		target.err = info.ObjectOf(errIdent)
+	}
+
+	removed := false
+
+	for _, stmts := range rest {
+		var leaks, terminated bool
+
+		removed, leaks, terminated = lc.walkPaths(stmts, target, removed)
+		if leaks {
+			return false
+		}
+
+		if terminated {
+			return true
+		}
+	}
+
+	// falling off the end of the function is a return path too.
+	return removed
+}
+
+// escapes reports whether obj, or a local variable derived from it, is
+// returned by the function or stored in a field, a global variable or a
+// channel.
+func (lc *leakChecker) escapes(obj types.Object) bool {
+	info := lc.reporterBuilder.pass.TypesInfo
+	values := lc.reporterBuilder.state.values
+	escapes := false
+
+	ast.Inspect(lc.body, func(node ast.Node) bool {
+		if escapes {
+			return false
+		}
+
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			for _, result := range node.Results {
+				escapes = escapes || values.carries(info, result, obj)
+			}
+		case *ast.SendStmt:
+			escapes = values.carries(info, node.Value, obj)
+		case *ast.AssignStmt:
+			escapes = len(node.Lhs) == len(node.Rhs) && lc.isStoredOutside(node, obj)
+		}
+
+		return !escapes
+	})
+
+	return escapes
+}
+
+func (lc *leakChecker) isStoredOutside(stmt *ast.AssignStmt, obj types.Object) bool {
+	info := lc.reporterBuilder.pass.TypesInfo
+
+	for index, lhs := range stmt.Lhs {
+		if isLocal(info, lhs) || !lc.reporterBuilder.state.values.carries(info, stmt.Rhs[index], obj) {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func isLocal(info *types.Info, expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	if ident.Name == "_" {
+		return true
+	}
+
+	variable, ok := info.ObjectOf(ident).(*types.Var)
+
+	return ok && isLocalVariable(variable)
+}
+
+// walkPaths follows the paths of stmts. It returns whether the path stored
+// in obj is removed at the end of stmts, whether a return statement is
+// reached before it is removed, and whether every path of stmts terminates,
+// by returning or by deferring the removal.
+func (lc *leakChecker) walkPaths(stmts []ast.Stmt,
+	target leakTarget,
+	removed bool,
+) (removedAfter, leaks, terminated bool) {
+	for _, stmt := range stmts {
+		removed, leaks, terminated = lc.walkPath(stmt, target, removed)
+		if leaks || terminated {
+			return removed, leaks, terminated
+		}
+	}
+
+	return removed, false, false
+}
+
+func (lc *leakChecker) walkPath(stmt ast.Stmt,
+	target leakTarget,
+	removed bool,
+) (removedAfter, leaks, terminated bool) {
+	switch stmt := stmt.(type) {
+	case *ast.DeferStmt:
+		// a deferred removal covers every return path that follows it.
+		return removed, false, lc.removes(stmt, target.path)
+	case *ast.ReturnStmt:
+		removed = removed || lc.removes(stmt, target.path)
+
+		return removed, !removed, true
+	case *ast.BlockStmt:
+		return lc.walkPaths(stmt.List, target, removed)
+	case *ast.LabeledStmt:
+		return lc.walkPath(stmt.Stmt, target, removed)
+	case *ast.IfStmt:
+		return lc.walkIfPaths(stmt, target, removed)
+	case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+		// the body may not run, so a removal inside it does not count after it.
+		for _, nested := range nestedStmtLists(stmt) {
+			if _, leaks, _ := lc.walkPaths(nested, target, removed); leaks {
+				return removed, true, false
+			}
+		}
+
+		return removed, false, false
+	default:
+		return removed || lc.removes(stmt, target.path), false, false
+	}
+}
+
+func (lc *leakChecker) walkIfPaths(stmt *ast.IfStmt,
+	target leakTarget,
+	removed bool,
+) (removedAfter, leaks, terminated bool) {
+	if stmt.Init != nil {
+		removed = removed || lc.removes(stmt.Init, target.path)
+	}
+
+	// the body of `if err != nil` runs when nothing was created.
+	if isNotNil(lc.reporterBuilder.pass.TypesInfo, stmt.Cond, target.err) {
+		if stmt.Else == nil {
+			return removed, false, false
+		}
+
+		return lc.walkPath(stmt.Else, target, removed)
+	}
+
+	// and the else branch of `if err == nil`.
+	if isEqualNil(lc.reporterBuilder.pass.TypesInfo, stmt.Cond, target.err) {
+		return lc.walkPaths(stmt.Body.List, target, removed)
+	}
+
+	bodyRemoved, leaks, bodyTerminated := lc.walkPaths(stmt.Body.List, target, removed)
+	if leaks {
+		return removed, true, false
+	}
+
+	elseRemoved, elseTerminated := removed, false
+
+	if stmt.Else != nil {
+		elseRemoved, leaks, elseTerminated = lc.walkPath(stmt.Else, target, removed)
+		if leaks {
+			return removed, true, false
+		}
+	}
+
+	return (bodyRemoved || bodyTerminated) && (elseRemoved || elseTerminated), false, bodyTerminated && elseTerminated
+}
+
+// removes reports whether node removes the path stored in obj.
+func (lc *leakChecker) removes(node ast.Node, obj types.Object) bool {
+	return removesPath(lc.reporterBuilder.pass.TypesInfo, node, obj)
+}
+
+// initStmt returns the init statement of an if or switch statement, if any.
+func initStmt(stmt ast.Stmt) ast.Stmt {
+	switch stmt := stmt.(type) {
+	case *ast.IfStmt:
+		return stmt.Init
+	case *ast.SwitchStmt:
+		return stmt.Init
+	case *ast.TypeSwitchStmt:
+		return stmt.Init
+	default:
+		return nil
+	}
+}
+
+// nestedStmtLists returns the statement lists nested in stmt, like the
+// body of a for statement or the clauses of a switch statement.
+func nestedStmtLists(stmt ast.Stmt) [][]ast.Stmt {
+	switch stmt := stmt.(type) {
+	case *ast.BlockStmt:
+		return [][]ast.Stmt{stmt.List}
+	case *ast.LabeledStmt:
+		return [][]ast.Stmt{{stmt.Stmt}}
+	case *ast.IfStmt:
+		lists := [][]ast.Stmt{stmt.Body.List}
+		if stmt.Else != nil {
+			lists = append(lists, []ast.Stmt{stmt.Else})
+		}
+
+		return lists
+	case *ast.ForStmt:
+		return [][]ast.Stmt{stmt.Body.List}
+	case *ast.RangeStmt:
+		return [][]ast.Stmt{stmt.Body.List}
+	case *ast.SwitchStmt:
+		return clauseBodies(stmt.Body)
+	case *ast.TypeSwitchStmt:
+		return clauseBodies(stmt.Body)
+	case *ast.SelectStmt:
+		return clauseBodies(stmt.Body)
+	default:
+		return nil
+	}
+}
+
+func clauseBodies(body *ast.BlockStmt) [][]ast.Stmt {
+	lists := make([][]ast.Stmt, 0, len(body.List))
+
+	for _, clause := range body.List {
+		switch clause := clause.(type) {
+		case *ast.CaseClause:
+			lists = append(lists, clause.Body)
+		case *ast.CommClause:
+			lists = append(lists, clause.Body)
+		}
+	}
+
+	return lists
+}
@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// ioutilReplacements maps the deprecated io/ioutil functions to the os
+// functions that replace them since Go 1.16.
+var ioutilReplacements = map[string]string{
+	"io/ioutil.TempDir":  "MkdirTemp",
+	"io/ioutil.TempFile": "CreateTemp",
+}
+
+// checkDeprecatedCalls reports the calls to the deprecated io/ioutil
+// functions in every file of the package, test or not. The calls rewritten
+// by the fixes of the testing rule are skipped, as well as the files that
+// target a Go version older than 1.16.
+func (ta *ttempdirAnalyzer) checkDeprecatedCalls(pass *analysis.Pass,
+	state *passState,
+	theInspector *inspector.Inspector,
+) {
+	reporterBuilder := newReporterBuilder(pass, state, "", "", "", nil)
+
+	theInspector.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		call, _ := node.(*ast.CallExpr)
+		if state.isSkipped(pass, call) || !reporterBuilder.supportsGoVersion(call.Pos(), goVersionMkdirTemp) {
+			return
+		}
+
+		function, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok {
+			return
+		}
+
+		replacement, ok := ioutilReplacements[function.FullName()]
+		if !ok {
+			return
+		}
+
+		if state.isRewritten(call.Fun) {
+			return
+		}
+
+		reporterBuilder.ReportDeprecated(call,
+			qualifiedFunctionName(function),
+			"os."+replacement,
+			reporterBuilder.modernizeFix(call, replacement)...,
+		)
+	})
+
+	reporterBuilder.flush()
+}
+
+// isRewritten reports whether expr is rewritten by the fix of a diagnostic
+// reported so far, like a call to ioutil.TempDir replaced by `t.TempDir()`.
+func (state *passState) isRewritten(expr ast.Expr) bool {
+	for _, diagnostic := range state.diagnostics {
+		if len(diagnostic.SuggestedFixes) == 0 {
+			continue
+		}
+
+		for _, edit := range diagnostic.SuggestedFixes[0].TextEdits {
+			if edit.Pos <= expr.Pos() && expr.End() <= edit.End {
+				return true
+			}
+		}
+	}
+
+	return false
+}
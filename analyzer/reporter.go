@@ -1,54 +1,391 @@
 package analyzer
 
 import (
+	"fmt"
+	"go/ast"
 	"go/token"
+	"go/types"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 )
 
 type passReporter struct {
-	builder  *passReporterBuilder
-	position token.Pos
+	builder        *passReporterBuilder
+	suggestedFixes []analysis.SuggestedFix
+	// removal is the call that removes the temporary directory or file, if any.
+	removal *ast.CallExpr
 }
 
-func (r *passReporter) Report(fullQualifiedFunctionName string) {
-	r.builder.Report(r.position, fullQualifiedFunctionName)
+func (r *passReporter) TypesInfo() *types.Info {
+	return r.builder.pass.TypesInfo
+}
+
+// Report reports the call of a temp dir function. The suggested fixes of
+// the reporter, if any, take precedence over the ones of the call.
+// If the function creates a temporary directory that is not removed, it is
+// reported as a leak.
+func (r *passReporter) Report(call *ast.CallExpr,
+	fullQualifiedFunctionName string,
+	creates bool,
+	callFixes []analysis.SuggestedFix,
+) {
+	r.builder.Report(call,
+		fullQualifiedFunctionName,
+		creates && r.removal == nil,
+		r.related("directory"),
+		r.fixesOr(callFixes)...)
+}
+
+// ReportTempFile reports the call of a temp file function.
+func (r *passReporter) ReportTempFile(call *ast.CallExpr,
+	fullQualifiedFunctionName string,
+	callFixes []analysis.SuggestedFix,
+) {
+	r.builder.ReportTempFile(call, fullQualifiedFunctionName, r.removal == nil, r.related("file"), r.fixesOr(callFixes)...)
+}
+
+// ReportFunction reports the call of a function of the configuration, with
+// its message instead of the default suggestion, if any.
+func (r *passReporter) ReportFunction(call *ast.CallExpr, fullQualifiedFunctionName, message string) {
+	if message == "" {
+		r.Report(call, fullQualifiedFunctionName, true, nil)
+
+		return
+	}
+
+	r.builder.ReportFunction(call, fullQualifiedFunctionName, r.removal == nil, r.related("directory"), message)
+}
+
+// nested returns the reporter of a call nested in the value reported by r,
+// like os.MkdirTemp in `dir := must(os.MkdirTemp("", "x"))`. Its result is
+// assigned to the same variable, but the fixes of the value do not apply.
+func (r *passReporter) nested() *passReporter {
+	return &passReporter{
+		builder: r.builder,
+		removal: r.removal,
+	}
+}
+
+func (r *passReporter) fixesOr(callFixes []analysis.SuggestedFix) []analysis.SuggestedFix {
+	if r.suggestedFixes != nil {
+		return r.suggestedFixes
+	}
+
+	return callFixes
+}
+
+// related returns the related information pointing to the removal of the
+// temporary directory or file, if any.
+func (r *passReporter) related(kind string) []analysis.RelatedInformation {
+	if r.removal == nil {
+		return nil
+	}
+
+	return []analysis.RelatedInformation{{
+		Pos:     r.removal.Pos(),
+		End:     r.removal.End(),
+		Message: "the temporary " + kind + " is removed here",
+	}}
+}
+
+func (r *passReporter) ReportCallChain(call *ast.CallExpr, fullQualifiedFunctionName string, fact *tempDirFact) {
+	r.builder.ReportCallChain(call, fullQualifiedFunctionName, fact)
 }
 
 type passReporterBuilder struct {
 	pass                  *analysis.Pass
+	state                 *passState
 	variableOrPackageName string
+	tempDirMethod         string
 	targetFunctionName    string
+	// declaration is the name of the testing parameter or receiver the
+	// suggestion is based on, if any.
+	declaration *ast.Ident
+	// findings are the findings not reported yet, see flush.
+	findings []*finding
 }
 
 func newReporterBuilder(pass *analysis.Pass,
-	variableOrPackageName, targetFunctionName string,
+	state *passState,
+	variableOrPackageName, tempDirMethod, targetFunctionName string,
+	declaration *ast.Ident,
 ) *passReporterBuilder {
-	if variableOrPackageName == "" {
-		variableOrPackageName = "testing"
-	}
-
 	return &passReporterBuilder{
 		pass:                  pass,
+		state:                 state,
 		variableOrPackageName: variableOrPackageName,
+		tempDirMethod:         tempDirMethod,
 		targetFunctionName:    targetFunctionName,
+		declaration:           declaration,
+	}
+}
+
+// TempDirCall returns the call expression suggested as replacement, like `t.TempDir()`.
+func (rb *passReporterBuilder) TempDirCall() string {
+	variableOrPackageName := rb.variableOrPackageName
+	if variableOrPackageName == "" {
+		variableOrPackageName = "testing"
+	}
+
+	return variableOrPackageName + "." + rb.tempDirMethod + "()"
+}
+
+// canFix reports whether the suggested replacement can be written at pos,
+// that is whether there is a testing value and its name is not shadowed at
+// pos, like by `for _, t := range names`.
+func (rb *passReporterBuilder) canFix(pos token.Pos) bool {
+	if rb.variableOrPackageName == "" {
+		return false
+	}
+
+	if rb.declaration == nil {
+		return true
+	}
+
+	file := fileOf(rb.pass, pos)
+	if file == nil || rb.pass.TypesInfo.Scopes[file] == nil {
+		return false
 	}
+
+	scope := rb.pass.TypesInfo.Scopes[file].Innermost(pos)
+	if scope == nil {
+		return false
+	}
+
+	_, obj := scope.LookupParent(rb.declaration.Name, pos)
+
+	return obj != nil && obj == rb.pass.TypesInfo.Defs[rb.declaration]
 }
 
-func (rb *passReporterBuilder) Build(position token.Pos) *passReporter {
+// supportsGoVersion reports whether the file that contains pos may use the
+// functions introduced by the Go version minimum.
+func (rb *passReporterBuilder) supportsGoVersion(pos token.Pos, minimum string) bool {
+	return isGoVersionAtLeast(fileGoVersion(rb.pass, rb.state.goVersion, pos), minimum)
+}
+
+// isInDefaultTempDir reports whether dir is empty, meaning the default
+// directory for temporary files, or derives from `os.TempDir()`.
+func (rb *passReporterBuilder) isInDefaultTempDir(dir ast.Expr) bool {
+	info := rb.pass.TypesInfo
+
+	return isEmptyString(info, dir) || rb.state.values.derivesFrom(info, dir, callMatcher(info, "os.TempDir"))
+}
+
+func (rb *passReporterBuilder) Build() *passReporter {
 	return &passReporter{
-		position: position,
-		builder:  rb,
+		builder: rb,
 	}
 }
 
-func (rb *passReporterBuilder) Report(position token.Pos,
+// BuildForAssign creates the reporters of the values of an assignment, by
+// index. The statements that follow it are used to suggest fixes and to find
+// out if the temporary directories or files are removed.
+func (rb *passReporterBuilder) BuildForAssign(stmt *ast.AssignStmt,
+	following []ast.Stmt,
+) []*passReporter {
+	reporters := rb.buildForValues(stmt, following)
+	if len(reporters) > 0 {
+		reporters[0].suggestedFixes = rb.assignFix(stmt, following)
+	}
+
+	return reporters
+}
+
+// BuildForIfInit creates the reporters of the values assigned by the init
+// statement of an if statement, which may be removed in its branches.
+func (rb *passReporterBuilder) BuildForIfInit(stmt *ast.IfStmt, assignStmt *ast.AssignStmt) []*passReporter {
+	branches := []ast.Stmt{stmt.Body}
+	if stmt.Else != nil {
+		branches = append(branches, stmt.Else)
+	}
+
+	return rb.buildForValues(assignStmt, branches)
+}
+
+// buildForValues creates a reporter for each value of stmt, with the
+// removal of the variable it is assigned to in the following statements.
+func (rb *passReporterBuilder) buildForValues(stmt *ast.AssignStmt, following []ast.Stmt) []*passReporter {
+	reporters := make([]*passReporter, 0, len(stmt.Rhs))
+
+	for index := range stmt.Rhs {
+		reporter := rb.Build()
+
+		if lhs, ok := assignedTo(stmt, index); ok {
+			reporter.removal, _ = rb.findRemovalLater(lhs, following)
+		}
+
+		reporters = append(reporters, reporter)
+	}
+
+	return reporters
+}
+
+// assignedTo returns the expression the value of stmt at index is assigned
+// to. The path returned by a temp dir or temp file function, like in
+// `dir, err := os.MkdirTemp("", "x")`, is its first result.
+func assignedTo(stmt *ast.AssignStmt, index int) (ast.Expr, bool) {
+	switch {
+	case len(stmt.Lhs) == len(stmt.Rhs):
+		return stmt.Lhs[index], true
+	case len(stmt.Rhs) == 1 && len(stmt.Lhs) > 0:
+		return stmt.Lhs[0], true
+	default:
+		return nil, false
+	}
+}
+
+func (rb *passReporterBuilder) Report(call *ast.CallExpr,
+	fullQualifiedFunctionName string,
+	leaks bool,
+	related []analysis.RelatedInformation,
+	suggestedFixes ...analysis.SuggestedFix,
+) {
+	rb.report(call, fullQualifiedFunctionName, leaks, "directory", related, suggestedFixes,
+		"%s() should be replaced by `%s` in %s",
+		fullQualifiedFunctionName,
+		rb.TempDirCall(),
+		rb.targetFunctionName,
+	)
+}
+
+func (rb *passReporterBuilder) ReportTempFile(call *ast.CallExpr,
 	fullQualifiedFunctionName string,
+	leaks bool,
+	related []analysis.RelatedInformation,
+	suggestedFixes ...analysis.SuggestedFix,
 ) {
-	rb.pass.Reportf(position,
-		"%s() should be replaced by `%s.TempDir()` in %s",
+	// before os.CreateTemp, the temporary file is still created by the same function.
+	createTemp := "os.CreateTemp"
+	if !rb.supportsGoVersion(call.Pos(), goVersionMkdirTemp) {
+		createTemp = fullQualifiedFunctionName
+	}
+
+	rb.report(call, fullQualifiedFunctionName, leaks, "file", related, suggestedFixes,
+		"%s() should be replaced by `%s(%s, ...)` in %s",
+		fullQualifiedFunctionName,
+		createTemp,
+		rb.TempDirCall(),
+		rb.targetFunctionName,
+	)
+}
+
+// ReportFunction reports the call of a function of the configuration with
+// its custom message.
+func (rb *passReporterBuilder) ReportFunction(call *ast.CallExpr,
+	fullQualifiedFunctionName string,
+	leaks bool,
+	related []analysis.RelatedInformation,
+	message string,
+) {
+	rb.report(call, fullQualifiedFunctionName, leaks, "directory", related, nil,
+		"%s() should be replaced in %s (%s)",
+		fullQualifiedFunctionName,
+		rb.targetFunctionName,
+		message,
+	)
+}
+
+// report records the finding of a call, in the style category, or in the
+// leak one when the temporary directory or file is never removed. It is
+// reported by flush, merged with the findings of the calls nested in it.
+// The declaration of the testing parameter, if any, is added to the related
+// information.
+func (rb *passReporterBuilder) report(call *ast.CallExpr,
+	fullQualifiedFunctionName string,
+	leaks bool,
+	kind string,
+	related []analysis.RelatedInformation,
+	suggestedFixes []analysis.SuggestedFix,
+	format string,
+	args ...interface{},
+) {
+	if rb.declaration != nil {
+		related = append([]analysis.RelatedInformation{{
+			Pos:     rb.declaration.Pos(),
+			End:     rb.declaration.End(),
+			Message: rb.declaration.Name + " is declared here",
+		}}, related...)
+	}
+
+	rb.findings = append(rb.findings, &finding{
+		call:           call,
+		functionName:   fullQualifiedFunctionName,
+		message:        fmt.Sprintf(format, args...),
+		leaks:          leaks,
+		kind:           kind,
+		related:        related,
+		suggestedFixes: suggestedFixes,
+	})
+}
+
+// ReportLeak reports a temporary directory or file created by non-test code
+// that is not removed on every return path.
+func (rb *passReporterBuilder) ReportLeak(call *ast.CallExpr,
+	fullQualifiedFunctionName string,
+	isFile bool,
+) {
+	kind := "directory"
+	if isFile {
+		kind = "file"
+	}
+
+	rb.pass.Report(analysis.Diagnostic{
+		Pos:      call.Pos(),
+		End:      call.End(),
+		Category: CategoryLeak,
+		Message: fmt.Sprintf("%s() creates a temporary %s that is not removed on every return path in %s",
+			fullQualifiedFunctionName,
+			kind,
+			rb.targetFunctionName,
+		),
+	})
+}
+
+// ReportDiscardedError reports a temporary directory or file created by
+// non-test code whose error is discarded.
+func (rb *passReporterBuilder) ReportDiscardedError(call *ast.CallExpr,
+	fullQualifiedFunctionName string,
+) {
+	rb.pass.Report(analysis.Diagnostic{
+		Pos:      call.Pos(),
+		End:      call.End(),
+		Category: CategoryStyle,
+		Message: fmt.Sprintf("the error of %s() is discarded in %s",
+			fullQualifiedFunctionName,
+			rb.targetFunctionName,
+		),
+	})
+}
+
+// ReportDeprecated reports a call to a deprecated io/ioutil function.
+func (rb *passReporterBuilder) ReportDeprecated(call *ast.CallExpr,
+	fullQualifiedFunctionName string,
+	replacement string,
+	suggestedFixes ...analysis.SuggestedFix,
+) {
+	rb.report(call, fullQualifiedFunctionName, false, "", nil, suggestedFixes,
+		"%s() is deprecated, use %s() instead",
+		fullQualifiedFunctionName,
+		replacement,
+	)
+}
+
+func (rb *passReporterBuilder) ReportCallChain(call *ast.CallExpr,
+	fullQualifiedFunctionName string,
+	fact *tempDirFact,
+) {
+	format := "%s() creates a temporary directory (%s), use `%s` instead in %s"
+	if !fact.Creates {
+		format = "%s() returns a path in the default temporary directory (%s), use `%s` instead in %s"
+	}
+
+	// whether the helper removes the temporary directory is not tracked.
+	rb.report(call, fullQualifiedFunctionName, false, "directory", nil, nil,
+		format,
 		fullQualifiedFunctionName,
-		rb.variableOrPackageName,
+		strings.Join(fact.Chain, " -> "),
+		rb.TempDirCall(),
 		rb.targetFunctionName,
 	)
 }
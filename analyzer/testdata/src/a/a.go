@@ -9,7 +9,7 @@ var (
 	_, ee = os.MkdirTemp("a", "b") // never seen
 )
 
-func setup() {
+func setup() { // want setup:"creates temporary directory via a\\.setup -> os\\.MkdirTemp"
 	os.MkdirTemp("a", "b")           // never seen
 	_, err := os.MkdirTemp("a", "b") // never seen
 	if err != nil {
@@ -19,7 +19,7 @@ func setup() {
 }
 
 func F(t *testing.T) {
-	setup()
+	setup()                          // want "a\\.setup\\(\\) creates a temporary directory \\(a\\.setup -> os\\.MkdirTemp\\), use `t\\.TempDir\\(\\)` instead in F"
 	os.MkdirTemp("a", "b")           // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in F"
 	_, err := os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in F"
 	_ = err
@@ -29,11 +29,11 @@ func F(t *testing.T) {
 
 	_ = func(t *testing.T) {
 		_ = t
-		_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
+		_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in F\\.func1"
 	}
 
 	t.Cleanup(func() {
-		_, _ = os.MkdirTemp("a", "b")
+		_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in F\\.func2"
 	})
 }
 
@@ -48,7 +48,7 @@ func BF(b *testing.B) {
 
 	func(b *testing.B) {
 		_ = b
-		_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `b\\.TempDir\\(\\)` in anonymous function"
+		_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `b\\.TempDir\\(\\)` in BF\\.func1"
 	}(b)
 }
 
@@ -62,7 +62,7 @@ func TBF(tb testing.TB) {
 
 	defer func(tb testing.TB) {
 		_ = tb
-		_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `tb\\.TempDir\\(\\)` in anonymous function"
+		_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `tb\\.TempDir\\(\\)` in TBF\\.func1"
 	}(tb)
 }
 
@@ -10,7 +10,7 @@ var (
 	_, e = os.MkdirTemp("a", "b") // never seen
 )
 
-func testsetup() {
+func testsetup() { // want testsetup:"creates temporary directory via a\\.testsetup -> os\\.MkdirTemp"
 	os.MkdirTemp("a", "b")           // if -all = true, want  "os\\.MkdirTemp\\(\\) should be replaced by `testing\\.TempDir\\(\\)` in testsetup"
 	_, err := os.MkdirTemp("a", "b") // if -all = true, want  "os\\.MkdirTemp\\(\\) should be replaced by `testing\\.TempDir\\(\\)` in testsetup"
 	if err != nil {
@@ -20,7 +20,7 @@ func testsetup() {
 }
 
 func TestF(t *testing.T) {
-	testsetup()
+	testsetup()                      // want "a\\.testsetup\\(\\) creates a temporary directory \\(a\\.testsetup -> os\\.MkdirTemp\\), use `t\\.TempDir\\(\\)` instead in TestF"
 	os.MkdirTemp("a", "b")           // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestF"
 	_, err := os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestF"
 	_ = err
@@ -58,12 +58,12 @@ func FuzzF(f *testing.F) {
 }
 
 func TestFunctionLiteral(t *testing.T) {
-	testsetup()
+	testsetup() // want "a\\.testsetup\\(\\) creates a temporary directory \\(a\\.testsetup -> os\\.MkdirTemp\\), use `t\\.TempDir\\(\\)` instead in TestFunctionLiteral"
 	t.Run("test", func(t *testing.T) {
-		os.MkdirTemp("a", "b")           // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
-		_, err := os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
+		os.MkdirTemp("a", "b")           // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFunctionLiteral/\"test\""
+		_, err := os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFunctionLiteral/\"test\""
 		_ = err
-		if _, err := os.MkdirTemp("a", "b"); err != nil { // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
+		if _, err := os.MkdirTemp("a", "b"); err != nil { // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFunctionLiteral/\"test\""
 			_ = err
 		}
 	})
@@ -84,10 +84,10 @@ func TestTDD(t *testing.T) {
 		{"test"},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
-			os.MkdirTemp("a", "b")           // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
-			_, err := os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
+			os.MkdirTemp("a", "b")           // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestTDD/tt\\.name"
+			_, err := os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestTDD/tt\\.name"
 			_ = err
-			if _, err := os.MkdirTemp("a", "b"); err != nil { // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
+			if _, err := os.MkdirTemp("a", "b"); err != nil { // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestTDD/tt\\.name"
 				_ = err
 			}
 		})
@@ -0,0 +1,51 @@
+package aa
+
+import (
+	"os"
+	"testing"
+)
+
+func TestTrailingIgnore(t *testing.T) {
+	t.Log(os.TempDir()) //ttempdir:ignore reason="asserts global TMPDIR"
+}
+
+func TestTrailingNolint(t *testing.T) {
+	t.Log(os.TempDir()) //nolint:errcheck,ttempdir // asserts global TMPDIR
+}
+
+func TestStatement(t *testing.T) {
+	//ttempdir:ignore reason="asserts global TMPDIR"
+	t.Log(
+		os.TempDir(),
+	)
+
+	t.Log(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestStatement"
+}
+
+// TestFunction asserts on the default directory.
+//
+//ttempdir:ignore reason="asserts global TMPDIR"
+func TestFunction(t *testing.T) {
+	t.Log(os.TempDir())
+
+	t.Run("sub", func(t *testing.T) {
+		t.Log(os.TempDir())
+	})
+}
+
+func TestBareNolint(t *testing.T) {
+	t.Log(os.TempDir()) //nolint
+}
+
+func TestOtherLinter(t *testing.T) {
+	t.Log(os.TempDir()) //nolint:errcheck // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestOtherLinter"
+}
+
+func TestMissingReason(t *testing.T) {
+	t.Log(os.TempDir()) //ttempdir:ignore // want `^//ttempdir:ignore directive requires a reason, like //ttempdir:ignore reason="..."$`
+
+	/* want `^//nolint:ttempdir directive requires a reason, like //nolint:ttempdir // reason$` */ //nolint:ttempdir
+	t.Log(os.TempDir())
+
+	t.Log(os.TempDir()) //ttempdir:ignore reason="" // want `^//ttempdir:ignore directive requires a reason`
+}
@@ -0,0 +1,19 @@
+package ab
+
+import (
+	"os"
+	"testing"
+)
+
+func TestUsed(t *testing.T) {
+	t.Log(os.TempDir()) //ttempdir:ignore reason="asserts global TMPDIR"
+}
+
+func TestUnused(t *testing.T) {
+	//ttempdir:ignore reason="no longer needed" // want `^unused //ttempdir:ignore directive$`
+	dir := t.TempDir()
+
+	t.Log(dir) //nolint:ttempdir // no longer needed // want `^unused //nolint:ttempdir directive$`
+
+	t.Log(dir) //nolint
+}
@@ -0,0 +1,3 @@
+module ab
+
+go 1.17
@@ -0,0 +1,17 @@
+package ac
+
+import (
+	"os"
+)
+
+type Env struct{}
+
+func (*Env) ScratchDir() string { return "" }
+
+func helper() string { // want helper:"returns a path in the default temporary directory via ac.helper -> os.TempDir"
+	return os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `testing\\.TempDir\\(\\)` in helper"
+}
+
+func checkEnv(env *Env) {
+	_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `env\\.ScratchDir\\(\\)` in checkEnv"
+}
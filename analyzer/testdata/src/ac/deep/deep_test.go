@@ -0,0 +1,9 @@
+package deep
+
+import (
+	"os"
+)
+
+func helper() string { // want helper:"returns a path in the default temporary directory via deep.helper -> os.TempDir"
+	return os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `testing\\.TempDir\\(\\)` in helper"
+}
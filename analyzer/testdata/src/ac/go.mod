@@ -0,0 +1,3 @@
+module ac
+
+go 1.17
@@ -0,0 +1,14 @@
+package nested
+
+import (
+	"os"
+	"testing"
+)
+
+func helper() string { // want helper:"returns a path in the default temporary directory via nested.helper -> os.TempDir"
+	return os.TempDir()
+}
+
+func TestNested(t *testing.T) {
+	t.Log(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestNested"
+}
@@ -0,0 +1,10 @@
+package ad // want "invalid configuration file .*\\.ttempdir\\.yaml: .*, the flags are used instead"
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAD(t *testing.T) {
+	t.Log(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestAD"
+}
@@ -0,0 +1,3 @@
+module ad
+
+go 1.17
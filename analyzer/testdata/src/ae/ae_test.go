@@ -0,0 +1,26 @@
+package ae
+
+import (
+	"testing"
+
+	"ae/fsutil"
+)
+
+func newScratch(n int) string { // want newScratch:"creates temporary directory via ae\\.newScratch -> fsutil\\.MakeScratch"
+	dir, _ := fsutil.MakeScratch("", n)
+
+	return dir
+}
+
+func TestMakeScratch(t *testing.T) {
+	dir, err := fsutil.MakeScratch("", 1) // want "fsutil\\.MakeScratch\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMakeScratch"
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = dir
+}
+
+func TestNewScratch(t *testing.T) {
+	_ = newScratch(2) // want "ae\\.newScratch\\(\\) creates a temporary directory \\(ae\\.newScratch -> fsutil\\.MakeScratch\\), use `t\\.TempDir\\(\\)` instead in TestNewScratch"
+}
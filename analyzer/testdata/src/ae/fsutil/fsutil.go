@@ -0,0 +1,14 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// MakeScratch creates a scratch directory under dir.
+func MakeScratch(dir string, n int) (string, error) {
+	scratch := filepath.Join(dir, "scratch-"+strconv.Itoa(n))
+
+	return scratch, os.Mkdir(scratch, 0o700)
+}
@@ -0,0 +1,3 @@
+module ae
+
+go 1.17
@@ -0,0 +1,40 @@
+package af
+
+import (
+	"os"
+	"testing"
+
+	"af/fsutil"
+	scratch "af/fsutil"
+)
+
+func TestMakeScratch(t *testing.T) {
+	dir, err := fsutil.MakeScratch("", "x") // want "fsutil\\.MakeScratch\\(\\) should be replaced in TestMakeScratch \\(use testfs\\.Scratch\\(t\\) instead\\), the temporary directory is never removed"
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = dir
+}
+
+func TestRenamedImport(t *testing.T) {
+	dir, err := scratch.MakeScratch("", "x") // want "fsutil\\.MakeScratch\\(\\) should be replaced in TestRenamedImport \\(use testfs\\.Scratch\\(t\\) instead\\)"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+}
+
+func TestFunctionValue(t *testing.T) {
+	// the calls of a function value are not resolved.
+	_, _ = fsutil.Scratch("", "x")
+}
+
+func MakeScratch(dir, name string) (string, error) {
+	return dir + name, nil
+}
+
+func TestSameName(t *testing.T) {
+	// another function with the same name.
+	_, _ = MakeScratch("", "x")
+}
@@ -0,0 +1,16 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// MakeScratch creates a scratch directory under dir.
+func MakeScratch(dir, name string) (string, error) {
+	scratch := filepath.Join(dir, name)
+
+	return scratch, os.Mkdir(scratch, 0o700)
+}
+
+// Scratch is an alias of MakeScratch.
+var Scratch = MakeScratch
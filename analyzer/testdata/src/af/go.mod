@@ -0,0 +1,3 @@
+module af
+
+go 1.18
@@ -0,0 +1,29 @@
+package ag
+
+import (
+	"testing"
+
+	"ag/scratch"
+)
+
+type embedded struct {
+	*scratch.FS
+}
+
+type path string
+
+func TestMethod(t *testing.T) {
+	fs := &scratch.FS{Root: "/tmp"}
+
+	_, _ = fs.TempDir("x") // want "scratch\\.FS\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMethod, the temporary directory is never removed"
+}
+
+func TestPromotedMethod(t *testing.T) {
+	fs := embedded{FS: &scratch.FS{Root: "/tmp"}}
+
+	_, _ = fs.TempDir("x") // want "scratch\\.FS\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestPromotedMethod, the temporary directory is never removed"
+}
+
+func TestGeneric(t *testing.T) {
+	_, _ = scratch.New[path]("/tmp", "x") // want "scratch\\.New\\(\\) should be replaced in TestGeneric \\(use scratch\\.FS\\{Root: t\\.TempDir\\(\\)\\} instead\\), the temporary directory is never removed"
+}
@@ -0,0 +1,25 @@
+package scratch
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// FS creates directories under its root.
+type FS struct {
+	Root string
+}
+
+// TempDir creates a directory under the root of fs.
+func (fs *FS) TempDir(name string) (string, error) {
+	dir := filepath.Join(fs.Root, name)
+
+	return dir, os.Mkdir(dir, 0o700)
+}
+
+// New creates a directory under root and returns it as a T.
+func New[T ~string](root, name T) (T, error) {
+	dir := filepath.Join(string(root), string(name))
+
+	return T(dir), os.Mkdir(dir, 0o700)
+}
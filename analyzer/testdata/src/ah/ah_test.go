@@ -0,0 +1,26 @@
+package ah
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAssign(t *testing.T) {
+	dir, err := os.MkdirTemp("", "assign") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestAssign"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+}
+
+func TestIfInit(t *testing.T) {
+	if dir, err := os.MkdirTemp("", "if"); err == nil { // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestIfInit"
+		t.Cleanup(func() { os.RemoveAll(dir) })
+	}
+}
+
+func TestCaptured(tb *testing.T) {
+	func() {
+		_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `tb\\.TempDir\\(\\)` in TestCaptured\\.func1"
+	}()
+}
@@ -0,0 +1,3 @@
+module ah
+
+go 1.17
@@ -0,0 +1,10 @@
+package ai
+
+import "io/ioutil"
+
+func Scratch() (string, string) { // want Scratch:"creates temporary directory via ai\\.Scratch -> ioutil\\.TempDir"
+	first, _ := ioutil.TempDir("", "a")  // want `ioutil.TempDir\(\) is deprecated, use os.MkdirTemp\(\) instead`
+	second, _ := ioutil.TempDir("", "b") // want `ioutil.TempDir\(\) is deprecated, use os.MkdirTemp\(\) instead`
+
+	return first, second
+}
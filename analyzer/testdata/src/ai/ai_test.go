@@ -0,0 +1,37 @@
+package ai
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestTempDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "a") // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestTempDir$"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Log(dir)
+}
+
+func TestTempFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "b") // want "ioutil\\.TempFile\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestTempFile$"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+
+	t.Log(f.Name())
+}
+
+func TestMkdirTemp(t *testing.T) {
+	dir, err := os.MkdirTemp("", "c") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTemp$"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Log(dir)
+}
@@ -0,0 +1,3 @@
+module ai
+
+go 1.17
@@ -9,7 +9,7 @@ var (
 	_, ee = ioutil.TempDir("a", "b") // never seen
 )
 
-func setup() {
+func setup() { // want setup:"creates temporary directory via b\\.setup -> ioutil\\.TempDir"
 	ioutil.TempDir("a", "b")           // never seen
 	_, err := ioutil.TempDir("a", "b") // never seen
 	if err != nil {
@@ -19,7 +19,7 @@ func setup() {
 }
 
 func F(t *testing.T) {
-	setup()
+	setup()                            // want "b\\.setup\\(\\) creates a temporary directory \\(b\\.setup -> ioutil\\.TempDir\\), use `t\\.TempDir\\(\\)` instead in F"
 	ioutil.TempDir("a", "b")           // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in F"
 	_, err := ioutil.TempDir("a", "b") // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in F"
 	_ = err
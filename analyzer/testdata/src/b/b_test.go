@@ -9,7 +9,7 @@ var (
 	_, e = ioutil.TempDir("a", "b") // never seen
 )
 
-func testsetup() {
+func testsetup() { // want testsetup:"creates temporary directory via b\\.testsetup -> ioutil\\.TempDir"
 	ioutil.TempDir("a", "b")           // if -all = true, want  "ioutil\\.TempDir\\(\\) should be replaced by `testing\\.TempDir\\(\\)` in testsetup"
 	_, err := ioutil.TempDir("a", "b") // if -all = true, want  "ioutil\\.TempDir\\(\\) should be replaced by `testing\\.TempDir\\(\\)` in testsetup"
 	if err != nil {
@@ -19,7 +19,7 @@ func testsetup() {
 }
 
 func TestF(t *testing.T) {
-	testsetup()
+	testsetup()                        // want "b\\.testsetup\\(\\) creates a temporary directory \\(b\\.testsetup -> ioutil\\.TempDir\\), use `t\\.TempDir\\(\\)` instead in TestF"
 	ioutil.TempDir("a", "b")           // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestF"
 	_, err := ioutil.TempDir("a", "b") // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestF"
 	_ = err
@@ -57,12 +57,12 @@ func FuzzF(f *testing.F) {
 }
 
 func TestFunctionLiteral(t *testing.T) {
-	testsetup()
+	testsetup() // want "b\\.testsetup\\(\\) creates a temporary directory \\(b\\.testsetup -> ioutil\\.TempDir\\), use `t\\.TempDir\\(\\)` instead in TestFunctionLiteral"
 	t.Run("test", func(t *testing.T) {
-		ioutil.TempDir("a", "b")           // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
-		_, err := ioutil.TempDir("a", "b") // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
+		ioutil.TempDir("a", "b")           // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFunctionLiteral/\"test\""
+		_, err := ioutil.TempDir("a", "b") // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFunctionLiteral/\"test\""
 		_ = err
-		if _, err := ioutil.TempDir("a", "b"); err != nil { // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
+		if _, err := ioutil.TempDir("a", "b"); err != nil { // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFunctionLiteral/\"test\""
 			_ = err
 		}
 	})
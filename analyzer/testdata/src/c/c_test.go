@@ -55,9 +55,9 @@ func FuzzF(f *testing.F) {
 func TestFunctionLiteral(t *testing.T) {
 	testsetup()
 	t.Run("test", func(t *testing.T) {
-		os.TempDir()                       // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
-		_ = os.TempDir()                   // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
-		if dir = os.TempDir(); dir != "" { // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
+		os.TempDir()                       // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFunctionLiteral/\"test\""
+		_ = os.TempDir()                   // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFunctionLiteral/\"test\""
+		if dir = os.TempDir(); dir != "" { // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFunctionLiteral/\"test\""
 			_ = dir
 		}
 	})
@@ -102,7 +102,7 @@ func TestRecursive(t *testing.T) {
 			filepath.Join( // recursion level 3
 				filepath.Clean( // recursion level 4
 					fmt.Sprintf("%s", // recursion level 5
-						os.TempDir(), // max recursion level reached.
+						os.TempDir(), // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestRecursive"
 					),
 				),
 				"test",
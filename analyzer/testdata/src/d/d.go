@@ -9,7 +9,7 @@ var (
 	_, ee = os.MkdirTemp("a", "b") // never seen
 )
 
-func setup() {
+func setup() { // want setup:"creates temporary directory via d\\.setup -> os\\.MkdirTemp"
 	os.MkdirTemp("a", "b")           // never seen
 	_, err := os.MkdirTemp("a", "b") // never seen
 	if err != nil {
@@ -19,7 +19,7 @@ func setup() {
 }
 
 func F(t *testing.T) {
-	setup()
+	setup()                          // want "d\\.setup\\(\\) creates a temporary directory \\(d\\.setup -> os\\.MkdirTemp\\), use `t\\.TempDir\\(\\)` instead in F"
 	os.MkdirTemp("a", "b")           // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in F"
 	_, err := os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in F"
 	_ = err
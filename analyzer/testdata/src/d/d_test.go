@@ -9,7 +9,7 @@ var (
 	_, e = os.MkdirTemp("a", "b") // never seen
 )
 
-func testsetup() {
+func testsetup() { // want testsetup:"creates temporary directory via d\\.testsetup -> os\\.MkdirTemp"
 	os.MkdirTemp("a", "b")           // want  "os\\.MkdirTemp\\(\\) should be replaced by `testing\\.TempDir\\(\\)` in testsetup"
 	_, err := os.MkdirTemp("a", "b") // want  "os\\.MkdirTemp\\(\\) should be replaced by `testing\\.TempDir\\(\\)` in testsetup"
 	if err != nil {
@@ -19,7 +19,7 @@ func testsetup() {
 }
 
 func TestF(t *testing.T) {
-	testsetup()
+	testsetup()                      // want "d\\.testsetup\\(\\) creates a temporary directory \\(d\\.testsetup -> os\\.MkdirTemp\\), use `t\\.TempDir\\(\\)` instead in TestF"
 	os.MkdirTemp("a", "b")           // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestF"
 	_, err := os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestF"
 	_ = err
@@ -27,7 +27,7 @@ func TestF(t *testing.T) {
 		_ = err
 	}
 	t.Cleanup(func() {
-		_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `testing\\.TempDir\\(\\)` in anonymous function"
+		_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestF\\.func1"
 	})
 }
 
@@ -60,12 +60,12 @@ func FuzzF(f *testing.F) {
 }
 
 func TestFunctionLiteral(t *testing.T) {
-	testsetup()
+	testsetup() // want "d\\.testsetup\\(\\) creates a temporary directory \\(d\\.testsetup -> os\\.MkdirTemp\\), use `t\\.TempDir\\(\\)` instead in TestFunctionLiteral"
 	t.Run("test", func(t *testing.T) {
-		os.MkdirTemp("a", "b")           // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
-		_, err := os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
+		os.MkdirTemp("a", "b")           // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFunctionLiteral/\"test\""
+		_, err := os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFunctionLiteral/\"test\""
 		_ = err
-		if _, err := os.MkdirTemp("a", "b"); err != nil { // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in anonymous function"
+		if _, err := os.MkdirTemp("a", "b"); err != nil { // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFunctionLiteral/\"test\""
 			_ = err
 		}
 	})
@@ -0,0 +1,34 @@
+package f
+
+import (
+	iou "io/ioutil"
+	tst "testing"
+)
+
+type fakeOS struct{}
+
+func (fakeOS) MkdirTemp(dir, pattern string) (string, error) { return dir + pattern, nil }
+
+func (fakeOS) TempDir() string { return "" }
+
+func AliasedImports(t *tst.T) {
+	iou.TempDir("a", "b")           // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in AliasedImports"
+	_, err := iou.TempDir("a", "b") // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in AliasedImports"
+	_ = err
+}
+
+func AliasedTB(tb tst.TB) {
+	iou.TempDir("a", "b") // want "ioutil\\.TempDir\\(\\) should be replaced by `tb\\.TempDir\\(\\)` in AliasedTB"
+}
+
+func LocalVariableNamedOS(t *tst.T) {
+	os := fakeOS{}
+	os.MkdirTemp("a", "b")
+	_, _ = os.MkdirTemp("a", "b")
+	_ = os.TempDir()
+}
+
+func LocalVariableNamedTesting(testing string) { // want LocalVariableNamedTesting:"creates temporary directory via f\\.LocalVariableNamedTesting -> ioutil\\.TempDir"
+	iou.TempDir("a", "b")
+	_ = testing
+}
@@ -0,0 +1,24 @@
+package f
+
+import (
+	. "os"
+	"testing"
+)
+
+type T = testing.T
+
+func TestDotImport(t *testing.T) {
+	MkdirTemp("a", "b")           // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestDotImport"
+	_, err := MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestDotImport"
+	_ = err
+	t.Log(TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestDotImport"
+}
+
+func TestTypeAlias(t *T) {
+	_ = TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestTypeAlias"
+}
+
+func TestShadowedFunction(t *testing.T) {
+	TempDir := func() string { return "" }
+	_ = TempDir()
+}
@@ -0,0 +1,138 @@
+package g
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestIfBody(t *testing.T) {
+	if testing.Short() {
+		os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestIfBody"
+	}
+}
+
+func TestIfCond(t *testing.T) {
+	if strings.HasPrefix(os.TempDir(), "/") { // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestIfCond"
+		return
+	}
+}
+
+func TestElse(t *testing.T) {
+	if testing.Short() {
+		return
+	} else {
+		os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestElse"
+	}
+}
+
+func TestElseIf(t *testing.T) {
+	if testing.Short() {
+		return
+	} else if _, err := os.MkdirTemp("a", "b"); err != nil { // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestElseIf"
+		return
+	}
+}
+
+func TestSwitch(t *testing.T) {
+	switch dir := os.TempDir(); dir { // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestSwitch"
+	case os.TempDir(): // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestSwitch"
+		os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestSwitch"
+	default:
+		_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestSwitch"
+	}
+
+	switch os.TempDir() { // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestSwitch"
+	case "":
+	}
+}
+
+func TestTypeSwitch(t *testing.T) {
+	var value interface{} = t
+
+	switch value.(type) {
+	case *testing.T:
+		os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestTypeSwitch"
+	}
+}
+
+func TestSelect(t *testing.T) {
+	ch := make(chan string, 1)
+
+	select {
+	case ch <- os.TempDir(): // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestSelect"
+		os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestSelect"
+	default:
+		_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestSelect"
+	}
+}
+
+func TestRange(t *testing.T) {
+	cases := []string{"a", "b"}
+
+	for _, tc := range cases {
+		os.MkdirTemp(tc, "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestRange"
+	}
+
+	for range os.Environ() {
+		_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestRange"
+	}
+}
+
+func TestFor(t *testing.T) {
+	for dir := os.TempDir(); dir != ""; dir = os.TempDir() { // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFor" "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestFor"
+		break
+	}
+}
+
+func TestBlock(t *testing.T) {
+	{
+		os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestBlock"
+	}
+}
+
+func TestLabeled(t *testing.T) {
+loop:
+	for {
+		os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestLabeled"
+
+		break loop
+	}
+}
+
+func TestGo(t *testing.T) {
+	go os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestGo"
+}
+
+func TestSend(t *testing.T) {
+	ch := make(chan string, 1)
+	ch <- os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestSend"
+}
+
+func TestDecl(t *testing.T) {
+	var dir, err = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestDecl"
+	_, _ = dir, err
+
+	var (
+		tmp     = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestDecl"
+		another string
+	)
+	_, _ = tmp, another
+}
+
+func returnDir(tb testing.TB) string {
+	return os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `tb\\.TempDir\\(\\)` in returnDir"
+}
+
+func returnMkdirTemp(tb testing.TB) (string, error) {
+	return os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `tb\\.TempDir\\(\\)` in returnMkdirTemp"
+}
+
+func returnError(tb testing.TB) error {
+	if _, err := os.Stat("a"); err != nil {
+		return errors.New(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `tb\\.TempDir\\(\\)` in returnError"
+	}
+
+	return nil
+}
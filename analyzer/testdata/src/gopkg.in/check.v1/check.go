@@ -0,0 +1,8 @@
+// Package check is a minimal stub of gopkg.in/check.v1 used by the analyzer tests.
+package check
+
+type C struct{}
+
+func (c *C) MkDir() string { return "" }
+
+func (c *C) Fatal(args ...interface{}) {}
@@ -0,0 +1,75 @@
+package h
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type Config struct {
+	Dir  string
+	Dirs []string
+}
+
+func (c *Config) Bar(dir string) *Config {
+	c.Dir = dir
+
+	return c
+}
+
+func foo() *Config { return &Config{} }
+
+func TestBinaryExpr(t *testing.T) {
+	_ = os.TempDir() + "/x"       // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestBinaryExpr"
+	t.Log("dir: " + os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestBinaryExpr"
+}
+
+func TestCompositeLit(t *testing.T) {
+	_ = &Config{Dir: os.TempDir()} // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestCompositeLit"
+	_ = []string{os.TempDir()}     // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestCompositeLit"
+	_ = Config{
+		Dirs: []string{
+			filepath.Join(os.TempDir(), "x"), // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestCompositeLit"
+		},
+	}
+}
+
+func TestIndexExpr(t *testing.T) {
+	m := map[string]string{}
+	_ = m[os.TempDir()]  // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestIndexExpr"
+	m[os.TempDir()] = "" // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestIndexExpr"
+
+	counters := map[string]int{}
+	counters[os.TempDir()]++ // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestIndexExpr"
+}
+
+func TestTypeAssertCall(t *testing.T) {
+	var x interface{} = fmt.Sprint
+	x.(func(...interface{}) string)(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestTypeAssertCall"
+}
+
+func TestChainedCall(t *testing.T) {
+	foo().Bar(os.TempDir())                  // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestChainedCall"
+	_ = foo().Bar("a").Bar(os.TempDir()).Dir // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestChainedCall"
+}
+
+func TestMultipleValues(t *testing.T) {
+	a, b := os.TempDir(), os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMultipleValues" "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMultipleValues"
+	_, _ = a, b
+}
+
+func TestUnaryAndParen(t *testing.T) {
+	dir := os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestUnaryAndParen"
+	_ = &dir
+	_ = len((os.TempDir())) > 0 // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestUnaryAndParen"
+	_ = !(os.TempDir() == "")   // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestUnaryAndParen"
+}
+
+func TestSliceExpr(t *testing.T) {
+	_ = os.TempDir()[1:] // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestSliceExpr"
+}
+
+func TestDeepNesting(t *testing.T) {
+	t.Log(fmt.Sprint(fmt.Sprint(fmt.Sprint(fmt.Sprint(fmt.Sprint(fmt.Sprint(fmt.Sprint(os.TempDir())))))))) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestDeepNesting"
+}
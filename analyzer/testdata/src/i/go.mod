@@ -0,0 +1,3 @@
+module i
+
+go 1.17
@@ -0,0 +1,42 @@
+package i
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecursionLimit(t *testing.T) {
+	t.Log( // recursion level 1
+		fmt.Sprintf("%s", // recursion level 2
+			os.TempDir()+"/x", // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestRecursionLimit"
+		),
+	)
+	t.Log( // recursion level 1
+		fmt.Sprintf("%s/foo-%d", // recursion level 2
+			filepath.Join( // recursion level 3
+				filepath.Clean( // recursion level 4
+					fmt.Sprintf("%s", // recursion level 5
+						os.TempDir(), // max recursion level reached.
+					),
+				),
+				"test",
+			),
+			1024,
+		),
+	)
+	_ = []string{ // recursion level 0
+		fmt.Sprint( // recursion level 1
+			fmt.Sprint( // recursion level 2
+				fmt.Sprint( // recursion level 3
+					fmt.Sprint( // recursion level 4
+						fmt.Sprint( // recursion level 5
+							os.TempDir(), // max recursion level reached.
+						),
+					),
+				),
+			),
+		),
+	}
+}
@@ -0,0 +1,88 @@
+package j
+
+import (
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestCleanup(t *testing.T) {
+	t.Cleanup(func() {
+		os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestCleanup\\.func1"
+	})
+}
+
+func TestGoroutine(t *testing.T) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestGoroutine\\.func1"
+	}()
+
+	wg.Wait()
+}
+
+func TestOnce(t *testing.T) {
+	var once sync.Once
+
+	once.Do(func() {
+		_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestOnce\\.func1"
+	})
+}
+
+func TestNestedClosures(t *testing.T) {
+	func() {
+		func() {
+			_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestNestedClosures\\.func1\\.1"
+		}()
+	}()
+}
+
+func TestNearestTestingVariable(t *testing.T) {
+	t.Run("sub", func(st *testing.T) {
+		st.Cleanup(func() {
+			_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `st\\.TempDir\\(\\)` in TestNearestTestingVariable/\"sub\"\\.func1"
+		})
+	})
+}
+
+func TestShadowedTestingVariable(t *testing.T) {
+	func(t string) {
+		_ = t
+		_ = os.TempDir()
+	}("")
+
+	func(*testing.T) {
+		func() {
+			_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestShadowedTestingVariable\\.func2\\.1"
+		}()
+	}(t)
+}
+
+type runner struct{}
+
+func (runner) Run(name string, f func()) { f() }
+
+func TestRunNotSubtest(t *testing.T) {
+	runner{}.Run("job", func() {
+		_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestRunNotSubtest\\.func1"
+	})
+}
+
+func BenchmarkRunParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = os.MkdirTemp("a", "b") // want "os\\.MkdirTemp\\(\\) should be replaced by `b\\.TempDir\\(\\)` in BenchmarkRunParallel\\.func1"
+		}
+	})
+}
+
+func helper() {
+	func() {
+		_ = os.TempDir()
+	}()
+}
@@ -0,0 +1,3 @@
+module k
+
+go 1.17
@@ -0,0 +1,35 @@
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type Fixture struct {
+	Dir string
+}
+
+func Setup() (string, error) { // want Setup:"creates temporary directory via testutil\\.Setup -> os\\.MkdirTemp"
+	return os.MkdirTemp("", "fixture")
+}
+
+func Nested() *Fixture { // want Nested:"creates temporary directory via testutil\\.Nested -> testutil\\.Setup -> os\\.MkdirTemp"
+	dir, _ := Setup()
+
+	return &Fixture{Dir: dir}
+}
+
+func (f *Fixture) Path() string { // want Path:"returns a path in the default temporary directory via testutil\\.Fixture\\.Path -> os\\.TempDir"
+	return filepath.Join(os.TempDir(), f.Dir)
+}
+
+func Clean(dir string) error {
+	return os.RemoveAll(dir)
+}
+
+func WithTB(tb testing.TB) string {
+	dir, _ := os.MkdirTemp("", "fixture") // want "os\\.MkdirTemp\\(\\) should be replaced by `tb\\.TempDir\\(\\)` in WithTB"
+
+	return dir
+}
@@ -0,0 +1,61 @@
+package k
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"k/internal/testutil"
+)
+
+var (
+	once sync.Once
+	dir  string
+)
+
+// lazyDir stores its temporary directory in a global variable, which is not followed.
+func lazyDir() string {
+	once.Do(func() {
+		dir, _ = os.MkdirTemp("", "lazy")
+	})
+
+	return dir
+}
+
+func first() string { // want first:"creates temporary directory via k\\.first -> k\\.second -> testutil\\.Setup -> os\\.MkdirTemp"
+	return second()
+}
+
+func second() string { // want second:"creates temporary directory via k\\.second -> testutil\\.Setup -> os\\.MkdirTemp"
+	dir, _ := testutil.Setup()
+
+	return dir
+}
+
+func recursive(n int) string { // want recursive:"returns a path in the default temporary directory via k\\.recursive -> os\\.TempDir"
+	if n > 0 {
+		return recursive(n - 1)
+	}
+
+	return os.TempDir()
+}
+
+func logDir() string { // want logDir:"returns a path in the default temporary directory via k\\.logDir -> os\\.TempDir"
+	return filepath.Join(os.TempDir(), "logs")
+}
+
+func Info(msg string) {
+	_ = logDir()
+	_ = msg
+}
+
+func Cleanup() {
+	dir, err := os.MkdirTemp("", "x")
+	if err == nil {
+		os.RemoveAll(dir)
+	}
+}
+
+func noTempDir() string {
+	return os.Getenv("HOME")
+}
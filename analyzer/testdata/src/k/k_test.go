@@ -0,0 +1,33 @@
+package k
+
+import (
+	"testing"
+
+	"k/internal/testutil"
+)
+
+func TestHelpers(t *testing.T) {
+	testutil.Setup()      // want "testutil\\.Setup\\(\\) creates a temporary directory \\(testutil\\.Setup -> os\\.MkdirTemp\\), use `t\\.TempDir\\(\\)` instead in TestHelpers"
+	_ = testutil.Nested() // want "testutil\\.Nested\\(\\) creates a temporary directory \\(testutil\\.Nested -> testutil\\.Setup -> os\\.MkdirTemp\\), use `t\\.TempDir\\(\\)` instead in TestHelpers"
+	_ = first()           // want "k\\.first\\(\\) creates a temporary directory \\(k\\.first -> k\\.second -> testutil\\.Setup -> os\\.MkdirTemp\\), use `t\\.TempDir\\(\\)` instead in TestHelpers"
+	_ = recursive(1)      // want "k\\.recursive\\(\\) returns a path in the default temporary directory \\(k\\.recursive -> os\\.TempDir\\), use `t\\.TempDir\\(\\)` instead in TestHelpers"
+	_ = lazyDir()
+}
+
+func TestLogging(t *testing.T) {
+	Info("message")
+	Cleanup()
+	_ = logDir() // want "k\\.logDir\\(\\) returns a path in the default temporary directory \\(k\\.logDir -> os\\.TempDir\\), use `t\\.TempDir\\(\\)` instead in TestLogging"
+}
+
+func TestMethod(t *testing.T) {
+	fixture := &testutil.Fixture{}
+	_ = fixture.Path() // want "testutil\\.Fixture\\.Path\\(\\) returns a path in the default temporary directory \\(testutil\\.Fixture\\.Path -> os\\.TempDir\\), use `t\\.TempDir\\(\\)` instead in TestMethod"
+}
+
+func TestNoFact(t *testing.T) {
+	_ = noTempDir()
+	_ = testutil.Clean("dir")
+	_ = testutil.WithTB(t)
+	_ = t.TempDir()
+}
@@ -0,0 +1,72 @@
+package l
+
+import (
+	"os"
+	"testing"
+)
+
+type tempDirer interface {
+	Helper()
+	TempDir() string
+}
+
+type wrapper struct {
+	*testing.T
+}
+
+type env struct {
+	t *testing.T
+}
+
+func (e *env) TempDir() string { return e.t.TempDir() }
+
+type wrongResult struct{}
+
+func (wrongResult) TempDir() int { return 0 }
+
+type wrongParams struct{}
+
+func (wrongParams) TempDir(string) string { return "" }
+
+func interfaceHelper(td tempDirer) {
+	td.Helper()
+	_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `td\\.TempDir\\(\\)` in interfaceHelper"
+}
+
+func anonymousInterfaceHelper(h interface{ TempDir() string }) {
+	_, _ = os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `h\\.TempDir\\(\\)` in anonymousInterfaceHelper"
+}
+
+func genericHelper[T testing.TB](t T) {
+	_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in genericHelper"
+}
+
+func wrapperHelper(w wrapper) {
+	_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `w\\.TempDir\\(\\)` in wrapperHelper"
+}
+
+func pointerReceiverHelper(e env) {
+	_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `e\\.TempDir\\(\\)` in pointerReceiverHelper"
+}
+
+func envHelper(e *env) {
+	_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `e\\.TempDir\\(\\)` in envHelper"
+}
+
+func wrongResultHelper(w wrongResult) {
+	_ = w
+	_ = os.TempDir()
+}
+
+func wrongParamsHelper(w wrongParams) {
+	_ = w
+	_ = os.TempDir()
+}
+
+func TestWrappers(t *testing.T) {
+	interfaceHelper(t)
+	anonymousInterfaceHelper(t)
+	genericHelper(t)
+	wrapperHelper(wrapper{t})
+	envHelper(&env{t})
+}
@@ -0,0 +1,7 @@
+module m
+
+go 1.17
+
+require github.com/stretchr/testify v1.0.0
+
+replace github.com/stretchr/testify => ../github.com/stretchr/testify
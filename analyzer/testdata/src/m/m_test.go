@@ -0,0 +1,54 @@
+package m
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StoreSuite struct {
+	suite.Suite
+
+	dir string
+}
+
+func (s *StoreSuite) SetupTest() {
+	s.dir, _ = os.MkdirTemp("", "store") // want "os\\.MkdirTemp\\(\\) should be replaced by `s\\.T\\(\\)\\.TempDir\\(\\)` in SetupTest"
+}
+
+func (s *StoreSuite) TestPut() {
+	os.MkdirTemp("", "put") // want "os\\.MkdirTemp\\(\\) should be replaced by `s\\.T\\(\\)\\.TempDir\\(\\)` in TestPut"
+}
+
+func (suite *StoreSuite) TestGet() {
+	suite.Run("subtest", func() {
+		_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `suite\\.T\\(\\)\\.TempDir\\(\\)` in TestGet/\"subtest\""
+	})
+}
+
+func (*StoreSuite) TestUnnamedReceiver() {
+	_ = os.TempDir()
+}
+
+func (s StoreSuite) TestValueReceiver() {
+	_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `s\\.T\\(\\)\\.TempDir\\(\\)` in TestValueReceiver"
+}
+
+type plain struct{}
+
+func (p *plain) TestNotASuite() {
+	_ = os.TempDir()
+}
+
+type wrongProvider struct{}
+
+func (wrongProvider) T() string { return "" }
+
+func (w wrongProvider) TestWrongProvider() {
+	_ = os.TempDir()
+}
+
+func TestStoreSuite(t *testing.T) {
+	suite.Run(t, new(StoreSuite))
+}
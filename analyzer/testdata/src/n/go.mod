@@ -0,0 +1,7 @@
+module n
+
+go 1.17
+
+require github.com/onsi/ginkgo/v2 v2.0.0
+
+replace github.com/onsi/ginkgo/v2 => ../github.com/onsi/ginkgo/v2
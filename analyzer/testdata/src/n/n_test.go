@@ -0,0 +1,46 @@
+package n
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("Store", func() {
+	var dir string
+
+	_ = os.TempDir()
+
+	BeforeEach(func() {
+		dir, _ = os.MkdirTemp("", "store") // want "os\\.MkdirTemp\\(\\) should be replaced by `GinkgoT\\(\\)\\.TempDir\\(\\)` in anonymous function"
+
+		DeferCleanup(func() {
+			_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `GinkgoT\\(\\)\\.TempDir\\(\\)` in anonymous function"
+		})
+	})
+
+	AfterEach(func() {
+		_ = dir
+	})
+
+	Context("when empty", func() {
+		It("puts", func() {
+			os.MkdirTemp("", "put") // want "os\\.MkdirTemp\\(\\) should be replaced by `GinkgoT\\(\\)\\.TempDir\\(\\)` in anonymous function"
+
+			go func() {
+				_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `GinkgoT\\(\\)\\.TempDir\\(\\)` in anonymous function"
+			}()
+		})
+
+		FIt("focused", func() {
+			_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `GinkgoT\\(\\)\\.TempDir\\(\\)` in anonymous function"
+		})
+	})
+
+	DescribeTable("table",
+		func(name string) {
+			_, _ = os.MkdirTemp("", name) // want "os\\.MkdirTemp\\(\\) should be replaced by `GinkgoT\\(\\)\\.TempDir\\(\\)` in anonymous function"
+		},
+		Entry("first", "a"),
+	)
+})
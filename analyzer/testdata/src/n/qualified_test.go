@@ -0,0 +1,13 @@
+package n
+
+import (
+	"os"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+var _ = ginkgo.Describe("Qualified", func() {
+	ginkgo.It("uses the package name", func() {
+		_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `ginkgo\\.GinkgoT\\(\\)\\.TempDir\\(\\)` in anonymous function"
+	})
+})
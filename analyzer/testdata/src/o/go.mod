@@ -0,0 +1,7 @@
+module o
+
+go 1.17
+
+require gopkg.in/check.v1 v1.0.0
+
+replace gopkg.in/check.v1 => ../gopkg.in/check.v1
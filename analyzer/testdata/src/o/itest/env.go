@@ -0,0 +1,5 @@
+package itest
+
+type Env struct{}
+
+func (env *Env) ScratchDir() string { return "" }
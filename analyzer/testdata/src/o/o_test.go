@@ -0,0 +1,44 @@
+package o
+
+import (
+	"os"
+	"testing"
+
+	check "gopkg.in/check.v1"
+
+	"o/itest"
+)
+
+type MySuite struct{}
+
+func (s *MySuite) TestMkdirTemp(c *check.C) {
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `c\\.MkDir\\(\\)` in TestMkdirTemp"
+	if err != nil {
+		c.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+}
+
+func (s *MySuite) TestTempDirInClosure(c *check.C) {
+	func() {
+		_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `c\\.MkDir\\(\\)` in TestTempDirInClosure\\.func1"
+	}()
+}
+
+func (s *MySuite) TestMkDir(c *check.C) {
+	_ = c.MkDir()
+	_, _ = os.MkdirTemp(c.MkDir(), "x")
+}
+
+func checkEnv(env *itest.Env) {
+	_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `env\\.ScratchDir\\(\\)` in checkEnv"
+	_ = env.ScratchDir()
+}
+
+func TestTesting(t *testing.T) {
+	_ = os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestTesting"
+}
+
+func helper() string { // want helper:"returns a path in the default temporary directory via o.helper -> os.TempDir"
+	return os.TempDir()
+}
@@ -0,0 +1,47 @@
+package p
+
+import (
+	"os"
+	"testing"
+)
+
+func TestCreateTemp(t *testing.T) {
+	f, err := os.CreateTemp("", "x") // want "os\\.CreateTemp\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestCreateTemp"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+}
+
+func TestCreateTempCleanup(t *testing.T) {
+	f, err := os.CreateTemp("", "x") // want "os\\.CreateTemp\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestCreateTempCleanup"
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.Remove(f.Name())
+	})
+
+	f.Close()
+}
+
+func TestCreateTempOnlyRemoved(t *testing.T) {
+	f, err := os.CreateTemp("", "x") // want "os\\.CreateTemp\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestCreateTempOnlyRemoved"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+}
+
+func TestCreateTempArgument(t *testing.T) {
+	t.Log(os.CreateTemp("", "x")) // want "os\\.CreateTemp\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestCreateTempArgument"
+}
+
+func TestCreateTempInDir(t *testing.T) {
+	f, err := os.CreateTemp("testdata", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+}
@@ -0,0 +1,112 @@
+package p
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMkdirTemp(t *testing.T) {
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTemp"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Log(dir)
+}
+
+func TestMkdirTempBlankError(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTempBlankError"
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	t.Log(dir)
+}
+
+func TestMkdirTempDeferredClosure(t *testing.T) {
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTempDeferredClosure"
+	if err != nil {
+		t.Fatalf("unable to create temporary directory: %v", err)
+	}
+
+	t.Log(dir)
+
+	defer func() {
+		os.RemoveAll(dir)
+	}()
+}
+
+func TestMkdirTempErrorRedeclared(t *testing.T) {
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTempErrorRedeclared"
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Create(filepath.Join(dir, "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+}
+
+func TestMkdirTempDirRedeclared(t *testing.T) {
+	var dir string
+
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTempDirRedeclared"
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log(dir)
+}
+
+func BenchmarkMkdirTemp(b *testing.B) {
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `b\\.TempDir\\(\\)` in BenchmarkMkdirTemp"
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	b.Log(dir)
+}
+
+func TestMkdirTempErrorUsed(t *testing.T) {
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTempErrorUsed"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Log(dir, err)
+}
+
+func TestMkdirTempErrorDeclaredBefore(t *testing.T) {
+	var err error
+
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTempErrorDeclaredBefore"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+}
+
+func TestMkdirTempElse(t *testing.T) {
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTempElse"
+	if err != nil {
+		t.Fatal(err)
+	} else {
+		defer os.RemoveAll(dir)
+	}
+}
+
+func TestMkdirTempShadowed(t *testing.T) {
+	for _, t := range []string{"a"} {
+		dir, err := os.MkdirTemp("", t) // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTempShadowed"
+		if err != nil {
+			panic(err)
+		}
+		defer os.RemoveAll(dir)
+
+		_ = filepath.Join(os.TempDir(), t) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTempShadowed"
+	}
+}
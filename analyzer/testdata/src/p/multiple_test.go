@@ -0,0 +1,19 @@
+package p
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMultipleCalls(t *testing.T) {
+	t.Log(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMultipleCalls"
+	t.Log(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMultipleCalls"
+
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMultipleCalls"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Log(dir)
+}
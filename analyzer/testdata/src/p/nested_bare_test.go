@@ -0,0 +1,10 @@
+package p
+
+import (
+	"os"
+	"testing"
+)
+
+func TestNestedBare(t *testing.T) {
+	os.MkdirTemp(os.TempDir(), "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestNestedBare, including the nested os\\.TempDir\\(\\), the temporary directory is never removed"
+}
@@ -0,0 +1,29 @@
+package p
+
+import (
+	"os"
+	"testing"
+)
+
+func TestNestedMkdirTemp(t *testing.T) {
+	dir, err := os.MkdirTemp(os.TempDir(), "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestNestedMkdirTemp, including the nested os\\.TempDir\\(\\)"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Log(dir)
+}
+
+func TestNestedCreateTemp(t *testing.T) {
+	f, err := os.CreateTemp(os.TempDir(), "x") // want "os\\.CreateTemp\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestNestedCreateTemp, including the nested os\\.TempDir\\(\\)"
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log(f.Name())
+}
+
+func TestNestedDeferred(t *testing.T) {
+	defer os.RemoveAll(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestNestedDeferred"
+}
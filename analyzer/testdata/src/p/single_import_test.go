@@ -0,0 +1,19 @@
+package p
+
+import "os"
+
+type T interface {
+	TempDir() string
+	Fatal(args ...interface{})
+	Log(args ...interface{})
+}
+
+func checkSingleImport(t T) {
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in checkSingleImport"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Log(dir)
+}
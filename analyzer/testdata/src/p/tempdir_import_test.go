@@ -0,0 +1,10 @@
+package p
+
+import (
+	"os"
+	"testing"
+)
+
+func TestTempDirImport(t *testing.T) {
+	t.Log(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestTempDirImport"
+}
@@ -0,0 +1,16 @@
+package p
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOSTempDir(t *testing.T) {
+	dir := os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestOSTempDir"
+	t.Log(dir)
+
+	t.Log(filepath.Join(os.TempDir(), "x")) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestOSTempDir"
+
+	_ = os.Getenv("HOME")
+}
@@ -0,0 +1,17 @@
+package p
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestTempFileKeepsIoutil(t *testing.T) {
+	f, err := ioutil.TempFile("", "x") // want "ioutil\\.TempFile\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestTempFileKeepsIoutil"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	data, err := ioutil.ReadFile(f.Name())
+	t.Log(data, err)
+}
@@ -0,0 +1,15 @@
+package p
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestTempFileWithoutOS(t *testing.T) {
+	f, err := ioutil.TempFile("", "x") // want "ioutil\\.TempFile\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestTempFileWithoutOS"
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.Close()
+}
@@ -0,0 +1,17 @@
+package p
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestTempFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "x") // want "ioutil\\.TempFile\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestTempFile"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+
+	f.Close()
+}
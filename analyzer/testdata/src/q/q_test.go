@@ -0,0 +1,66 @@
+package q
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const defaultDir = ""
+
+func TestCreateTemp(t *testing.T) {
+	f, err := os.CreateTemp("", "x") // want "os\\.CreateTemp\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestCreateTemp"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+}
+
+func TestTempFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "x") // want "ioutil\\.TempFile\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestTempFile"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+}
+
+func TestCreateTempConstant(t *testing.T) {
+	_, _ = os.CreateTemp(defaultDir, "x") // want "os\\.CreateTemp\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestCreateTempConstant"
+}
+
+func TestCreateTempInOSTempDir(t *testing.T) {
+	_, _ = os.CreateTemp(os.TempDir(), "x") // want "os\\.CreateTemp\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestCreateTempInOSTempDir, including the nested os\\.TempDir\\(\\)"
+}
+
+func TestTempFileInOSTempDirSubdirectory(t *testing.T) {
+	_, _ = ioutil.TempFile(filepath.Join(os.TempDir(), "sub"), "x") // want "ioutil\\.TempFile\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestTempFileInOSTempDirSubdirectory, including the nested os\\.TempDir\\(\\)"
+}
+
+func TestCreateTempThroughVariables(t *testing.T) {
+	tmp := os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestCreateTempThroughVariables"
+
+	var dir string
+	dir = filepath.Join(tmp, "sub")
+
+	_, _ = os.CreateTemp(dir, "x") // want "os\\.CreateTemp\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestCreateTempThroughVariables"
+}
+
+func TestCreateTempInClosure(t *testing.T) {
+	t.Run("sub", func(t *testing.T) {
+		_, _ = os.CreateTemp("", "x") // want "os\\.CreateTemp\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestCreateTempInClosure/\"sub\""
+	})
+}
+
+func TestCreateTempInOtherDirectories(t *testing.T) {
+	_, _ = os.CreateTemp("testdata", "x")
+	_, _ = os.CreateTemp(t.TempDir(), "x")
+	_, _ = ioutil.TempFile(filepath.Join("testdata", "sub"), "x")
+
+	dir := "testdata"
+	_, _ = os.CreateTemp(dir, "x")
+}
+
+func createTemp() (*os.File, error) {
+	return os.CreateTemp("", "x")
+}
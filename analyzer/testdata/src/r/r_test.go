@@ -0,0 +1,50 @@
+package r
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMkdirTempInTempDir(t *testing.T) {
+	_, _ = os.MkdirTemp(t.TempDir(), "sub-*")
+	_, _ = ioutil.TempDir(filepath.Join(t.TempDir(), "sub"), "x")
+}
+
+func TestMkdirTempThroughVariables(t *testing.T) {
+	dir := t.TempDir()
+	_, _ = os.MkdirTemp(dir, "x")
+
+	parent, err := os.MkdirTemp(dir, "parent-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	child := filepath.Join(parent, "child")
+	_, _ = os.MkdirTemp(child, "x")
+}
+
+func TestMkdirTempInClosure(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("sub", func(t *testing.T) {
+		_, _ = os.MkdirTemp(dir, "x")
+	})
+}
+
+func BenchmarkMkdirTempInTempDir(b *testing.B) {
+	_, _ = os.MkdirTemp(b.TempDir(), "x")
+}
+
+func helper(tb testing.TB) {
+	_, _ = os.MkdirTemp(tb.TempDir(), "x")
+}
+
+func TestMkdirTempInOtherDirectories(t *testing.T) {
+	_, _ = os.MkdirTemp("", "x")           // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTempInOtherDirectories"
+	_, _ = os.MkdirTemp(os.TempDir(), "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTempInOtherDirectories, including the nested os\\.TempDir\\(\\)"
+
+	dir := "testdata"
+	_, _ = os.MkdirTemp(dir, "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestMkdirTempInOtherDirectories"
+}
@@ -0,0 +1,108 @@
+package s
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestRemovedInDefer(t *testing.T) {
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestRemovedInDefer$"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+}
+
+func TestRemovedInCleanup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "x") // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestRemovedInCleanup$"
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestRemovedLater(t *testing.T) {
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestRemovedLater$"
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log(dir)
+
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRemovedInIfInit(t *testing.T) {
+	if dir, err := os.MkdirTemp("", "x"); err == nil { // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestRemovedInIfInit$"
+		defer os.RemoveAll(dir)
+	}
+}
+
+func TestNeverRemoved(t *testing.T) {
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestNeverRemoved, the temporary directory is never removed"
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log(dir)
+}
+
+func TestOtherDirectoryRemoved(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestOtherDirectoryRemoved, the temporary directory is never removed"
+	other := dir + "-other"
+
+	defer os.RemoveAll(other)
+}
+
+func TestNotAssigned(t *testing.T) {
+	t.Log(os.MkdirTemp("", "x")) // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestNotAssigned, the temporary directory is never removed"
+}
+
+func TestTempDir(t *testing.T) {
+	dir := os.TempDir() // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestTempDir$"
+	t.Log(dir)
+}
+
+func TestFileRemoved(t *testing.T) {
+	f, err := os.CreateTemp("", "x") // want "os\\.CreateTemp\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestFileRemoved$"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+}
+
+func TestFileNeverRemoved(t *testing.T) {
+	f, err := os.CreateTemp("", "x") // want "os\\.CreateTemp\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestFileNeverRemoved, the temporary file is never removed"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+}
+
+func must(dir string, err error) string {
+	if err != nil {
+		panic(err)
+	}
+
+	return dir
+}
+
+func TestRemovedNested(t *testing.T) {
+	dir := must(os.MkdirTemp("", "x")) // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestRemovedNested$"
+	defer os.RemoveAll(dir)
+}
+
+func TestRemovedMultipleValues(t *testing.T) {
+	first, second := must(os.MkdirTemp("", "a")), must(os.MkdirTemp("", "b")) // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestRemovedMultipleValues, the temporary directory is never removed" "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestRemovedMultipleValues$"
+	defer os.RemoveAll(second)
+
+	t.Log(first)
+}
@@ -0,0 +1,218 @@
+package t
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+var errNotFound = errors.New("not found")
+
+type Workspace struct {
+	dir string
+}
+
+func process(string) error { return nil }
+
+func RemovedInDefer() error {
+	dir, err := os.MkdirTemp("", "x")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	return process(dir)
+}
+
+func RemovedOnEveryPath(found bool) error {
+	dir, err := ioutil.TempDir("", "x")
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		os.RemoveAll(dir)
+
+		return errNotFound
+	}
+
+	err = process(dir)
+	_ = os.RemoveAll(dir)
+
+	return err
+}
+
+func RemovedInReturn() error {
+	dir, err := os.MkdirTemp("", "x")
+	if err != nil {
+		return err
+	}
+
+	if err := process(dir); err != nil {
+		os.RemoveAll(dir)
+
+		return err
+	}
+
+	return os.RemoveAll(dir)
+}
+
+func Returned() (string, error) { // want Returned:"creates temporary directory via t\\.Returned -> os\\.MkdirTemp"
+	dir, err := os.MkdirTemp("", "x")
+	if err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+func ReturnedJoined() (string, error) { // want ReturnedJoined:"creates temporary directory via t\\.ReturnedJoined -> os\\.MkdirTemp"
+	dir, err := os.MkdirTemp("", "x")
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "out"), nil
+}
+
+func ReturnedInStruct() (*Workspace, error) { // want ReturnedInStruct:"creates temporary directory via t\\.ReturnedInStruct -> os\\.MkdirTemp"
+	dir, err := os.MkdirTemp("", "x")
+	if err != nil {
+		return nil, err
+	}
+
+	workspace := &Workspace{dir: dir}
+
+	return workspace, nil
+}
+
+func (w *Workspace) StoredInField() error {
+	dir, err := os.MkdirTemp("", "x")
+	if err != nil {
+		return err
+	}
+
+	w.dir = dir
+
+	return nil
+}
+
+func NeverRemoved() error { // want NeverRemoved:"creates temporary directory via t\\.NeverRemoved -> os\\.MkdirTemp"
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) creates a temporary directory that is not removed on every return path in NeverRemoved"
+	if err != nil {
+		return err
+	}
+
+	return process(dir)
+}
+
+func RemovedOnSomePaths(found bool) error { // want RemovedOnSomePaths:"creates temporary directory via t\\.RemovedOnSomePaths -> os\\.MkdirTemp"
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) creates a temporary directory that is not removed on every return path in RemovedOnSomePaths"
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errNotFound
+	}
+
+	defer os.RemoveAll(dir)
+
+	return process(dir)
+}
+
+func RemovedInLoop(names []string) { // want RemovedInLoop:"creates temporary directory via t\\.RemovedInLoop -> os\\.MkdirTemp"
+	dir, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) creates a temporary directory that is not removed on every return path in RemovedInLoop"
+	if err != nil {
+		return
+	}
+
+	for range names {
+		os.RemoveAll(dir)
+	}
+}
+
+func DiscardedError() {
+	dir, _ := os.MkdirTemp("", "x") // want "the error of os\\.MkdirTemp\\(\\) is discarded in DiscardedError"
+	defer os.RemoveAll(dir)
+
+	_ = process(dir)
+}
+
+func DiscardedPath() error { // want DiscardedPath:"creates temporary directory via t\\.DiscardedPath -> os\\.MkdirTemp"
+	_, err := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) creates a temporary directory that is not removed on every return path in DiscardedPath"
+
+	return err
+}
+
+func DiscardedResults() { // want DiscardedResults:"creates temporary directory via t\\.DiscardedResults -> os\\.MkdirTemp"
+	os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) creates a temporary directory that is not removed on every return path in DiscardedResults"
+}
+
+func FileRemoved() error {
+	f, err := os.CreateTemp("", "x")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	return f.Close()
+}
+
+func FileNeverRemoved() error {
+	f, err := os.CreateTemp("", "x") // want "os\\.CreateTemp\\(\\) creates a temporary file that is not removed on every return path in FileNeverRemoved"
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+func InClosure() {
+	go func() {
+		dir, _ := os.MkdirTemp("", "x") // want "the error of os\\.MkdirTemp\\(\\) is discarded in InClosure\\.func1" "os\\.MkdirTemp\\(\\) creates a temporary directory that is not removed on every return path in InClosure\\.func1"
+		_ = process(dir)
+	}()
+}
+
+func CreatedInIfInit() { // want CreatedInIfInit:"creates temporary directory via t\\.CreatedInIfInit -> os\\.MkdirTemp"
+	if dir, err := os.MkdirTemp("", "x"); err == nil { // want "os\\.MkdirTemp\\(\\) creates a temporary directory that is not removed on every return path in CreatedInIfInit"
+		_ = process(dir)
+	}
+}
+
+func RemovedInIfInit() error {
+	if dir, err := os.MkdirTemp("", "x"); err == nil {
+		defer os.RemoveAll(dir)
+
+		return process(dir)
+	}
+
+	return errNotFound
+}
+
+func CreatedInSwitchInit() error { // want CreatedInSwitchInit:"creates temporary directory via t\\.CreatedInSwitchInit -> os\\.MkdirTemp"
+	switch dir, err := os.MkdirTemp("", "x"); err { // want "os\\.MkdirTemp\\(\\) creates a temporary directory that is not removed on every return path in CreatedInSwitchInit"
+	case nil:
+		return process(dir)
+	default:
+		return err
+	}
+}
+
+func DeclaredVar() { // want DeclaredVar:"creates temporary directory via t\\.DeclaredVar -> os\\.MkdirTemp"
+	var dir, _ = os.MkdirTemp("", "y") // want "the error of os\\.MkdirTemp\\(\\) is discarded in DeclaredVar" "os\\.MkdirTemp\\(\\) creates a temporary directory that is not removed on every return path in DeclaredVar"
+
+	_ = process(dir)
+}
+
+func DeclaredVarRemoved() error {
+	var dir, err = os.MkdirTemp("", "y")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	return process(dir)
+}
@@ -0,0 +1,16 @@
+package t
+
+import (
+	"os"
+	"testing"
+)
+
+func TestNeverRemoved(t *testing.T) {
+	dir, _ := os.MkdirTemp("", "x") // want "os\\.MkdirTemp\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestNeverRemoved, the temporary directory is never removed"
+	t.Log(dir)
+}
+
+func helper() { // want helper:"creates temporary directory via t\\.helper -> os\\.MkdirTemp"
+	dir, _ := os.MkdirTemp("", "x")
+	_ = dir
+}
@@ -0,0 +1,3 @@
+module u
+
+go 1.17
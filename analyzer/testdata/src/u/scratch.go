@@ -0,0 +1,20 @@
+package u
+
+import (
+	"io/ioutil"
+	"os"
+)
+
+func writeScratch(data []byte) (string, error) {
+	file, err := ioutil.TempFile(os.Getenv("SCRATCH"), "scratch") // want `ioutil.TempFile\(\) is deprecated, use os.CreateTemp\(\) instead`
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if err := ioutil.WriteFile(file.Name(), data, 0o600); err != nil {
+		return "", err
+	}
+
+	return file.Name(), nil
+}
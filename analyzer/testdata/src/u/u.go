@@ -0,0 +1,8 @@
+package u
+
+import "io/ioutil"
+
+// NewWorkDir creates a work directory.
+func NewWorkDir() (string, error) { // want NewWorkDir:"creates temporary directory via u.NewWorkDir -> ioutil.TempDir"
+	return ioutil.TempDir("", "work") // want `ioutil.TempDir\(\) is deprecated, use os.MkdirTemp\(\) instead`
+}
@@ -0,0 +1,15 @@
+package u
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestSubDir(t *testing.T) {
+	dir, err := ioutil.TempDir(t.TempDir(), "sub") // want `ioutil.TempDir\(\) is deprecated, use os.MkdirTemp\(\) instead`
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = dir
+}
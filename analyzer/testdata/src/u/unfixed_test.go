@@ -0,0 +1,17 @@
+package u
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestUnfixed(t *testing.T) {
+	ioutil.TempDir("", "bare") // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestUnfixed, the temporary directory is never removed" `ioutil.TempDir\(\) is deprecated, use os.MkdirTemp\(\) instead`
+
+	dir, err := ioutil.TempDir("", "used") // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestUnfixed, the temporary directory is never removed" `ioutil.TempDir\(\) is deprecated, use os.MkdirTemp\(\) instead`
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log(dir, err)
+}
@@ -0,0 +1,17 @@
+package u
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestWorkDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "work") // want "ioutil\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestWorkDir"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_ = dir
+}
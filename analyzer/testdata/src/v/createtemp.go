@@ -0,0 +1,17 @@
+//go:build go1.16
+
+package v
+
+import "io/ioutil"
+
+func writeConfig(data []byte) error {
+	file, err := ioutil.TempFile("", "config") // want `ioutil.TempFile\(\) is deprecated, use os.CreateTemp\(\) instead`
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+
+	return err
+}
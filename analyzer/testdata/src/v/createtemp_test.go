@@ -0,0 +1,17 @@
+//go:build go1.16
+
+package v
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestCreateTemp(t *testing.T) {
+	f, err := ioutil.TempFile("", "x") // want "ioutil\\.TempFile\\(\\) should be replaced by `os\\.CreateTemp\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestCreateTemp"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+}
@@ -0,0 +1,12 @@
+//go:build go1.18
+
+package v
+
+import (
+	"os"
+	"testing"
+)
+
+func FuzzTempDir(f *testing.F) {
+	f.Log(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `f\\.TempDir\\(\\)` in FuzzTempDir"
+}
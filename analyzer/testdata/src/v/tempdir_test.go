@@ -0,0 +1,19 @@
+//go:build go1.15
+
+package v
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestTempDir(t *testing.T) {
+	t.Log(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestTempDir"
+
+	f, err := ioutil.TempFile("", "x") // want "ioutil\\.TempFile\\(\\) should be replaced by `ioutil\\.TempFile\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestTempDir"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+}
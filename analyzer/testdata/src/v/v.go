@@ -0,0 +1,15 @@
+package v
+
+import "io/ioutil"
+
+func writeScratch(data []byte) error {
+	file, err := ioutil.TempFile("", "scratch")
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+
+	return err
+}
@@ -0,0 +1,17 @@
+package v
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestBeforeTempDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Log(os.TempDir())
+}
@@ -0,0 +1,19 @@
+package w
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestTempFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "x") // want "ioutil\\.TempFile\\(\\) should be replaced by `ioutil\\.TempFile\\(t\\.TempDir\\(\\), \\.\\.\\.\\)` in TestTempFile"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+}
+
+func FuzzTempDir(f *testing.F) {
+	f.Log(os.TempDir())
+}
@@ -0,0 +1,10 @@
+package x
+
+import (
+	"os"
+	"testing"
+)
+
+func TestX(t *testing.T) {
+	t.Log(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestX"
+}
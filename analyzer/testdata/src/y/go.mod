@@ -0,0 +1,3 @@
+module y
+
+go 1.17
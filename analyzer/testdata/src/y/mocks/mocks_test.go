@@ -0,0 +1,10 @@
+package mocks
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMocks(t *testing.T) {
+	t.Log(os.TempDir())
+}
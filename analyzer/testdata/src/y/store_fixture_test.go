@@ -0,0 +1,10 @@
+package y
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFixture(t *testing.T) {
+	t.Log(os.TempDir())
+}
@@ -0,0 +1,10 @@
+package y
+
+import (
+	"os"
+	"testing"
+)
+
+func TestY(t *testing.T) {
+	t.Log(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestY"
+}
@@ -0,0 +1,10 @@
+package z
+
+import (
+	"os"
+	"testing"
+)
+
+func TestChecked(t *testing.T) {
+	t.Log(os.TempDir()) // want "os\\.TempDir\\(\\) should be replaced by `t\\.TempDir\\(\\)` in TestChecked"
+}
@@ -0,0 +1,3 @@
+module z
+
+go 1.17
@@ -0,0 +1,10 @@
+package sub
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSub(t *testing.T) {
+	t.Log(os.TempDir())
+}
@@ -0,0 +1,10 @@
+package z
+
+import (
+	"os"
+	"testing"
+)
+
+func TestZ(t *testing.T) {
+	t.Log(os.TempDir())
+}
@@ -0,0 +1,78 @@
+package analyzer
+
+import (
+	"go/ast"
+)
+
+// exprVisitor walks an expression tree looking for calls to check.
+// Function literals are not visited, they are checked on their own.
+type exprVisitor struct {
+	ta              *ttempdirAnalyzer
+	reporterBuilder *passReporterBuilder
+	reporter        *passReporter
+	root            ast.Expr
+	level           uint
+}
+
+// newExprVisitor creates an exprVisitor. If reporter is not nil, it is
+// used when the visited expression is itself a call, like the value of an
+// assignment, and the calls nested in it get its nested reporter. Otherwise
+// each call gets its own reporter.
+func newExprVisitor(ta *ttempdirAnalyzer,
+	reporterBuilder *passReporterBuilder,
+	reporter *passReporter,
+) *exprVisitor {
+	return &exprVisitor{
+		ta:              ta,
+		reporterBuilder: reporterBuilder,
+		reporter:        reporter,
+	}
+}
+
+func (v *exprVisitor) walk(expr ast.Expr) {
+	if expr == nil {
+		return
+	}
+
+	v.root = expr
+
+	ast.Walk(v, expr)
+
+	v.reporterBuilder.flush()
+}
+
+func (v *exprVisitor) Visit(node ast.Node) ast.Visitor {
+	switch node := node.(type) {
+	case *ast.FuncLit:
+		return nil
+	case *ast.CallExpr:
+		return v.visitCallExpr(node)
+	}
+
+	return v
+}
+
+func (v *exprVisitor) visitCallExpr(callExpr *ast.CallExpr) ast.Visitor {
+	if v.ta.MaxRecursionLevel > 0 && v.level >= v.ta.MaxRecursionLevel {
+		return nil
+	}
+
+	v.ta.checkFunctionExpr(v.reporterFor(callExpr), callExpr)
+
+	next := *v
+	next.level++
+
+	return &next
+}
+
+func (v *exprVisitor) reporterFor(callExpr *ast.CallExpr) *passReporter {
+	if v.reporter == nil {
+		return v.reporterBuilder.Build()
+	}
+
+	if ast.Expr(callExpr) == v.root {
+		return v.reporter
+	}
+
+	return v.reporter.nested()
+}
@@ -7,6 +7,7 @@ toolchain go1.22.10
 require (
 	github.com/gostaticanalysis/testutil v0.5.2
 	golang.org/x/tools v0.29.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (